@@ -0,0 +1,114 @@
+package uuid
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"sync/atomic"
+)
+
+// Chain is a Next chain with a custom step: every uuid of the chain is the previous one plus the step,
+// added to the 112 bits which are not in the 6th and 8th byte, the same way as UUID.Next.
+// Chains with different steps are different, so a secret step makes the chain hard to guess from the seed.
+type Chain struct {
+	seed UUID
+	step Uint128
+}
+
+// NewChain creates a Chain starting with seed. step must be an odd number of at most 112 bits (14 bytes),
+// nil means the default step of UUID.Next, so the chain is the same as calling UUID.Next.
+// Returns an error for Nil, Max and malformed seeds and for invalid steps.
+func NewChain(seed UUID, step *big.Int) (*Chain, error) {
+	if seed == Nil {
+		return nil, errors.New("missing uuid")
+	}
+
+	// validates the seed the same way as Next
+	if _, err := seed.Next(); err != nil {
+		return nil, err
+	}
+
+	c := &Chain{seed: UUID(strings.ToLower(seed.String())), step: primeStep}
+
+	if step != nil {
+		if step.Sign() <= 0 || step.Bit(0) == 0 || step.BitLen() > 112 {
+			return nil, errors.New("invalid chain step, must be odd and at most 14 bytes long: " + step.String())
+		}
+
+		var b [size]byte
+		step.FillBytes(b[:])
+		c.step = uint128FromBytes(b)
+	}
+
+	return c, nil
+}
+
+// Seed returns the first uuid of the chain.
+func (c *Chain) Seed() UUID {
+	return c.seed
+}
+
+// At returns the n-th uuid of the chain, At(0) is the seed.
+func (c *Chain) At(n uint64) UUID {
+	u, _ := c.seed.shift(c.step.Mul64(n))
+
+	return u
+}
+
+// Next returns the uuid after u in the chain, u does not have to be generated by the chain.
+// Returns Nil for Nil and an error for Max and malformed uuids.
+func (c *Chain) Next(u UUID) (UUID, error) {
+	return u.shift(c.step)
+}
+
+// Prev returns the uuid before u in the chain, the inverse of Next.
+// Returns Nil for Nil and an error for Max and malformed uuids.
+func (c *Chain) Prev(u UUID) (UUID, error) {
+	return u.shift(Uint128{}.Sub(c.step))
+}
+
+// Sequencer returns a Sequencer handing out the uuids of the chain after the seed.
+func (c *Chain) Sequencer() *Sequencer {
+	return &Sequencer{chain: c}
+}
+
+// Sequencer hands out the successive uuids of a chain, safe for concurrent use:
+// every uuid is returned exactly once, without locking.
+type Sequencer struct {
+	chain  *Chain
+	offset atomic.Uint64
+}
+
+// NewSequencer creates a Sequencer for the Next chain started by seed, the first uuid returned is seed.Next().
+// To continue after a restart, pass the value of a previous sequencer's Cursor().
+// Returns an error for Nil, Max and malformed seeds. See Chain.Sequencer for chains with a custom step.
+func NewSequencer(seed UUID) (*Sequencer, error) {
+	c, err := NewChain(seed, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Sequencer(), nil
+}
+
+// Next returns the next uuid of the chain.
+func (s *Sequencer) Next() UUID {
+	return s.chain.At(s.offset.Add(1))
+}
+
+// Skip reserves the next n uuids of the chain, eg: for a batch, and returns the first of them.
+// The others follow it in the chain, see Chain.Next (the same as UUID.Next for NewSequencer).
+// Concurrent calls reserve distinct blocks. Returns Nil for n = 0.
+func (s *Sequencer) Skip(n uint64) UUID {
+	if n == 0 {
+		return Nil
+	}
+
+	return s.chain.At(s.offset.Add(n) - n + 1)
+}
+
+// Cursor returns the last uuid returned by Next or skipped by Skip, the seed if there is none yet.
+// It can be used as a checkpoint, see NewSequencer.
+func (s *Sequencer) Cursor() UUID {
+	return s.chain.At(s.offset.Load())
+}
@@ -0,0 +1,292 @@
+package uuid
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+)
+
+func TestSequencer(t *testing.T) {
+	seed := UUID("afe40693-8f63-4766-85f1-250a427f1db5")
+
+	s, err := NewSequencer(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := s.Cursor(); got != seed {
+		t.Errorf("want: %v, got: %v", seed, got)
+	}
+
+	want := seed
+	for i := 0; i < 10; i++ {
+		if want, err = want.Next(); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := s.Next(); got != want {
+			t.Errorf("want: %v, got: %v", want, got)
+		}
+	}
+
+	if got := s.Cursor(); got != want {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+
+	first, err := want.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Skip(5); got != first {
+		t.Errorf("want: %v, got: %v", first, got)
+	}
+	if want, err = want.NextN(5); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Cursor(); got != want {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+	if got := s.Skip(0); got != Nil {
+		t.Errorf("want: Nil, got: %v", got)
+	}
+
+	// continue from a checkpoint
+	resumed, err := NewSequencer(s.Cursor())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a, b := s.Next(), resumed.Next(); a != b {
+		t.Errorf("resumed sequencer is different: %v != %v", a, b)
+	}
+}
+
+func TestSequencerUpper(t *testing.T) {
+	s, err := NewSequencer("AFE40693-8F63-4766-85F1-250A427F1DB5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want, got := UUID("dca97cf2-e0f5-477f-8529-4c98ed7564ce"), s.Next(); want != got {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+}
+
+func TestSequencerError(t *testing.T) {
+	for _, data := range []struct {
+		name string
+		seed UUID
+	}{
+		{name: "nil", seed: Nil},
+		{name: "max", seed: Max},
+		{name: "malformed", seed: "asda"},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			if _, err := NewSequencer(data.seed); err == nil {
+				t.Errorf("expected error, but got nothing for %v", data.seed)
+			}
+		})
+	}
+}
+
+func TestSequencerConcurrent(t *testing.T) {
+	s, err := NewSequencer("afe40693-8f63-4766-85f1-250a427f1db5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const goroutines, count = 16, 1000
+
+	var mu sync.Mutex
+	uuids := make(map[UUID]struct{}, goroutines*count)
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < count; j++ {
+				u := s.Next()
+
+				mu.Lock()
+				if _, ok := uuids[u]; ok {
+					t.Errorf("duplicate uuid: %v", u)
+				}
+				uuids[u] = struct{}{}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	want, err := UUID("afe40693-8f63-4766-85f1-250a427f1db5").NextN(goroutines * count)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Cursor(); got != want {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+}
+
+func TestSequencerSkipConcurrent(t *testing.T) {
+	s, err := NewSequencer("afe40693-8f63-4766-85f1-250a427f1db5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const goroutines, batch = 16, 10
+
+	var mu sync.Mutex
+	uuids := make(map[UUID]struct{}, goroutines*batch)
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			u := s.Skip(batch)
+			for j := 0; j < batch; j++ {
+				mu.Lock()
+				if _, ok := uuids[u]; ok {
+					t.Errorf("duplicate uuid: %v", u)
+				}
+				uuids[u] = struct{}{}
+				mu.Unlock()
+
+				next, err := u.Next()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				u = next
+			}
+		}()
+	}
+	wg.Wait()
+
+	// the blocks cover the chain without gaps
+	u := UUID("afe40693-8f63-4766-85f1-250a427f1db5")
+	for i := 0; i < goroutines*batch; i++ {
+		if u, err = u.Next(); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := uuids[u]; !ok {
+			t.Errorf("missing uuid: %v", u)
+		}
+	}
+}
+
+func TestChainDefaultStep(t *testing.T) {
+	seed := UUID("afe40693-8f63-4766-85f1-250a427f1db5")
+
+	c, err := NewChain(seed, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := c.Seed(); got != seed {
+		t.Errorf("want: %v, got: %v", seed, got)
+	}
+
+	// the same values as UUID.Next
+	for i, want := range []UUID{seed, "dca97cf2-e0f5-477f-8529-4c98ed7564ce", "096ef352-3287-4797-8561-7427986babe7"} {
+		if got := c.At(uint64(i)); got != want {
+			t.Errorf("At(%v) want: %v, got: %v", i, want, got)
+		}
+	}
+
+	c, err = NewChain(seed, bigPrime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := UUID("dca97cf2-e0f5-477f-8529-4c98ed7564ce"), c.At(1); want != got {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+}
+
+func TestChainCustomStep(t *testing.T) {
+	seed := UUID("afe40693-8f63-4766-85f1-250a427f1db5")
+
+	c, err := NewChain(seed, big.NewInt(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, want := range []UUID{seed, "afe40693-8f63-4766-85f1-250a427f1db8", "afe40693-8f63-4766-85f1-250a427f1dbb"} {
+		if got := c.At(uint64(i)); got != want {
+			t.Errorf("At(%v) want: %v, got: %v", i, want, got)
+		}
+	}
+
+	// consistent, and different from the default chain
+	next1, err := c.Next(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	next2, err := c.Next(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next1 != next2 {
+		t.Errorf("uuid is different, %v != %v", next1, next2)
+	}
+	if defaultNext, _ := seed.Next(); next1 == defaultNext {
+		t.Errorf("custom step resulted in the default chain: %v", next1)
+	}
+
+	prev, err := c.Prev(next1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prev != seed {
+		t.Errorf("want: %v, got: %v", seed, prev)
+	}
+
+	s := c.Sequencer()
+	if want, got := c.At(1), s.Next(); want != got {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+}
+
+func TestChainStepUnique(t *testing.T) {
+	step, _ := new(big.Int).SetString("ffffffffffffffffffffffffffff", 16)
+
+	c, err := NewChain("afe40693-8f63-4766-85f1-250a427f1db5", step)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uuids := make(map[UUID]struct{})
+	for i := uint64(0); i < 10000; i++ {
+		u := c.At(i)
+		if _, ok := uuids[u]; ok {
+			t.Fatalf("duplicate uuid at %v: %v", i, u)
+		}
+		uuids[u] = struct{}{}
+	}
+}
+
+func TestNewChainError(t *testing.T) {
+	tooBig := new(big.Int).Lsh(big.NewInt(1), 112)
+	tooBig.Add(tooBig, big.NewInt(1))
+
+	for _, data := range []struct {
+		name string
+		seed UUID
+		step *big.Int
+	}{
+		{name: "nil seed", seed: Nil},
+		{name: "max seed", seed: Max},
+		{name: "malformed seed", seed: "asda"},
+		{name: "even step", seed: "afe40693-8f63-4766-85f1-250a427f1db5", step: big.NewInt(4)},
+		{name: "zero step", seed: "afe40693-8f63-4766-85f1-250a427f1db5", step: big.NewInt(0)},
+		{name: "negative step", seed: "afe40693-8f63-4766-85f1-250a427f1db5", step: big.NewInt(-3)},
+		{name: "step longer than 14 bytes", seed: "afe40693-8f63-4766-85f1-250a427f1db5", step: tooBig},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			if _, err := NewChain(data.seed, data.step); err == nil {
+				t.Errorf("expected error, but got nothing for %v, %v", data.seed, data.step)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package uuid
+
+// crc8Table is the lookup table of CRC-8 with the polynomial x^8 + x^2 + x + 1 (0x07).
+var crc8Table = func() (table [256]byte) {
+	for i := range table {
+		crc := byte(i)
+		for j := 0; j < 8; j++ {
+			if crc&0x80 != 0 {
+				crc = crc<<1 ^ 0x07
+			} else {
+				crc <<= 1
+			}
+		}
+		table[i] = crc
+	}
+
+	return table
+}()
+
+func crc8(b []byte) byte {
+	var crc byte
+	for _, c := range b {
+		crc = crc8Table[crc^c]
+	}
+
+	return crc
+}
+
+// WithChecksum replaces the last byte of the uuid with the CRC-8 checksum of the other 15 bytes, eg: to detect
+// typos in uuids entered by humans, see VerifyChecksum. Every single character typo is detected,
+// and so is swapping two adjacent characters. The version and variant bits are kept.
+// @warning - The checksum reduces the entropy of the uuid by 8 bits, the last byte of u is lost.
+// Returns Nil for Nil and malformed uuids.
+func WithChecksum(u UUID) UUID {
+	if u == Nil {
+		return Nil
+	}
+
+	b, err := u.decode()
+	if err != nil {
+		return Nil
+	}
+
+	b[15] = crc8(b[:15])
+
+	return UUID(string(encodeBytes(b[:])))
+}
+
+// VerifyChecksum reports whether the last byte of the uuid is the checksum set by WithChecksum.
+// Returns false for Nil and malformed uuids.
+func VerifyChecksum(u UUID) bool {
+	if u == Nil {
+		return false
+	}
+
+	b, err := u.decode()
+	if err != nil {
+		return false
+	}
+
+	return b[15] == crc8(b[:15])
+}
+
+// StripChecksum verifies the checksum of the uuid and returns it with the checksum byte set to zero,
+// the other 15 bytes of the uuid passed to WithChecksum. Returns an error for invalid checksums,
+// Nil and malformed uuids.
+func StripChecksum(u UUID) (UUID, error) {
+	if !VerifyChecksum(u) {
+		return Nil, errInvalid("invalid uuid checksum: " + u.String())
+	}
+
+	b, _ := u.decode()
+	b[15] = 0
+
+	return UUID(string(encodeBytes(b[:]))), nil
+}
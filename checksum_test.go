@@ -0,0 +1,97 @@
+package uuid
+
+import (
+	"testing"
+)
+
+func TestCRC8(t *testing.T) {
+	// check value of CRC-8/SMBUS
+	if got := crc8([]byte("123456789")); got != 0xf4 {
+		t.Errorf("want: 0xf4, got: %#x", got)
+	}
+}
+
+func TestWithChecksum(t *testing.T) {
+	for _, data := range []struct {
+		original UUID
+		want     UUID
+	}{
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", want: "afe40693-8f63-4766-85f1-250a427f1d04"},
+		{original: "AFE40693-8F63-4766-85F1-250A427F1DB5", want: "afe40693-8f63-4766-85f1-250a427f1d04"},
+		{original: Nil, want: Nil},
+		{original: "asda", want: Nil},
+	} {
+		got := WithChecksum(data.original)
+		if got != data.want {
+			t.Errorf("want: %v, got: %v", data.want, got)
+		}
+	}
+
+	for i := 0; i < 1000; i++ {
+		u := WithChecksum(NewV4())
+		if _, err := FromString(u.String()); err != nil {
+			t.Fatal(err)
+		}
+		if !VerifyChecksum(u) {
+			t.Fatalf("invalid checksum: %v", u)
+		}
+
+		stripped, err := StripChecksum(u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u[:34] != stripped[:34] || stripped[34:] != "00" {
+			t.Errorf("want: %v00, got: %v", u[:34], stripped)
+		}
+		if again := WithChecksum(stripped); again != u {
+			t.Errorf("want: %v, got: %v", u, again)
+		}
+	}
+}
+
+func TestVerifyChecksumTypos(t *testing.T) {
+	u := WithChecksum(NewV4())
+
+	for i := 0; i < len(u); i++ {
+		if u[i] == '-' {
+			continue
+		}
+
+		// every single character typo
+		for _, c := range []byte("0123456789abcdef") {
+			if c == u[i] {
+				continue
+			}
+
+			typo := u[:i] + UUID(c) + u[i+1:]
+			if VerifyChecksum(typo) {
+				t.Errorf("typo not detected in %v: %v", u, typo)
+			}
+		}
+
+		// swapping adjacent characters
+		j := i + 1
+		if j < len(u) && u[j] == '-' {
+			j++
+		}
+		if j < len(u) && u[i] != u[j] {
+			b := []byte(u)
+			b[i], b[j] = b[j], b[i]
+			if swapped := UUID(b); VerifyChecksum(swapped) {
+				t.Errorf("swap not detected in %v: %v", u, swapped)
+			}
+		}
+	}
+}
+
+func TestVerifyChecksumError(t *testing.T) {
+	for _, orig := range []UUID{Nil, "asda", "afe40693-8f63-4766-85f1-250a427f1db5"} {
+		if VerifyChecksum(orig) {
+			t.Errorf("want: false, got: true for %v", orig)
+		}
+
+		if _, err := StripChecksum(orig); err == nil {
+			t.Errorf("expected error, but got nothing for %v", orig)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package uuid
+
+import (
+	"bytes"
+	"math/big"
+	"math/bits"
+	"strings"
+)
+
+// Compare compares the 128 bits values of a and b and returns -1 if a < b, 0 if a == b and +1 if a > b.
+// Case is ignored, and Nil (like the all-zero uuid) sorts before everything else. Time uuids (see NewTime)
+// are ordered by their timestamp. Malformed uuids sort after every well-formed one, ordered as strings.
+// It can be used with slices.SortFunc.
+func Compare(a, b UUID) int {
+	ab, aErr := a.compareBytes()
+	bb, bErr := b.compareBytes()
+
+	switch {
+	case aErr != nil && bErr != nil:
+		return strings.Compare(string(a), string(b))
+	case aErr != nil:
+		return 1
+	case bErr != nil:
+		return -1
+	default:
+		return bytes.Compare(ab[:], bb[:])
+	}
+}
+
+// Compare compares u and v, see Compare.
+func (u UUID) Compare(v UUID) int {
+	return Compare(u, v)
+}
+
+func (u UUID) compareBytes() ([size]byte, error) {
+	if u == Nil {
+		return [size]byte{}, nil
+	}
+
+	return u.decode()
+}
+
+// Equal reports whether a and b are the same uuid, ignoring case. Nil and the all-zero uuid are equal,
+// malformed uuids are not equal to anything, not even to themselves.
+// Plain == is safe for uuids returned by the parsers and generators of this package, as those are always
+// lowercase and use Nil for the all-zero uuid. Equal is needed for uuids created by casting, eg: UUID(str).
+func Equal(a, b UUID) bool {
+	ab, err := a.compareBytes()
+	if err != nil {
+		return false
+	}
+
+	bb, err := b.compareBytes()
+	if err != nil {
+		return false
+	}
+
+	return ab == bb
+}
+
+// Equal reports whether u and v are the same uuid, see Equal.
+func (u UUID) Equal(v UUID) bool {
+	return Equal(u, v)
+}
+
+// CommonPrefixBits returns the number of leading bits a and b share, 128 if they are equal.
+// Case is ignored. Returns ErrNil if any of them is Nil and an error for malformed uuids.
+func CommonPrefixBits(a, b UUID) (int, error) {
+	x, err := xorBits(a, b)
+	if err != nil {
+		return 0, err
+	}
+
+	if x.Hi != 0 {
+		return bits.LeadingZeros64(x.Hi), nil
+	}
+
+	return 64 + bits.LeadingZeros64(x.Lo), nil
+}
+
+// xorBits returns the bitwise XOR of the 128 bits values of a and b.
+func xorBits(a, b UUID) (Uint128, error) {
+	ab, err := a.Bytes()
+	if err != nil {
+		return Uint128{}, err
+	}
+
+	bb, err := b.Bytes()
+	if err != nil {
+		return Uint128{}, err
+	}
+
+	return uint128FromBytes(ab).Xor(uint128FromBytes(bb)), nil
+}
+
+// Distance returns the absolute difference of the 128 bits values of a and b.
+// Case is ignored. Returns ErrNil if any of them is Nil and an error for malformed uuids.
+func Distance(a, b UUID) (*big.Int, error) {
+	ab, err := a.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	bb, err := b.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	d := new(big.Int).SetBytes(ab[:])
+	d.Sub(d, new(big.Int).SetBytes(bb[:]))
+
+	return d.Abs(d), nil
+}
+
+// HammingDistance returns the number of bits which are different in a and b.
+// Case is ignored. Returns ErrNil if any of them is Nil and an error for malformed uuids.
+func HammingDistance(a, b UUID) (int, error) {
+	x, err := xorBits(a, b)
+	if err != nil {
+		return 0, err
+	}
+
+	return bits.OnesCount64(x.Hi) + bits.OnesCount64(x.Lo), nil
+}
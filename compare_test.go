@@ -0,0 +1,165 @@
+package uuid
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCompare(t *testing.T) {
+	for _, data := range []struct {
+		a, b UUID
+		want int
+	}{
+		{a: Nil, b: Nil, want: 0},
+		{a: Nil, b: "00000000-0000-0000-0000-000000000000", want: 0},
+		{a: Nil, b: "00000000-0000-4000-8000-000000000001", want: -1},
+		{a: "00000000-0000-4000-8000-000000000001", b: Nil, want: 1},
+		{a: "afe40693-8f63-4766-85f1-250a427f1db5", b: "AFE40693-8F63-4766-85F1-250A427F1DB5", want: 0},
+		{a: "afe40693-8f63-4766-85f1-250a427f1db5", b: "B0000000-0000-4000-8000-000000000000", want: -1},
+		{a: "AFE40693-8F63-4766-85F1-250A427F1DB5", b: "afe40693-8f63-4766-85f1-250a427f1db6", want: -1},
+		{a: Max, b: "afe40693-8f63-4766-85f1-250a427f1db5", want: 1},
+		{a: "asda", b: Max, want: 1},
+		{a: Max, b: "asda", want: -1},
+		{a: "asda", b: "asdb", want: -1},
+		{a: "asda", b: "asda", want: 0},
+	} {
+		if got := Compare(data.a, data.b); got != data.want {
+			t.Errorf("Compare(%v, %v) want: %v, got: %v", data.a, data.b, data.want, got)
+		}
+		if got := data.a.Compare(data.b); got != data.want {
+			t.Errorf("%v.Compare(%v) want: %v, got: %v", data.a, data.b, data.want, got)
+		}
+	}
+}
+
+func TestCompareTime(t *testing.T) {
+	now := time.Now()
+	want := []UUID{Nil, NewTime(now), NewTime(now.Add(time.Second)), NewTime(now.Add(time.Hour))}
+
+	got := []UUID{want[3], want[1], want[0], want[2]}
+	slices.SortFunc(got, Compare)
+
+	if !slices.Equal(got, want) {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+}
+
+func TestEqual(t *testing.T) {
+	for _, data := range []struct {
+		a, b UUID
+		want bool
+	}{
+		{a: Nil, b: Nil, want: true},
+		{a: Nil, b: "00000000-0000-0000-0000-000000000000", want: true},
+		{a: "00000000-0000-0000-0000-000000000000", b: "00000000-0000-0000-0000-000000000000", want: true},
+		{a: "afe40693-8f63-4766-85f1-250a427f1db5", b: "AFE40693-8F63-4766-85F1-250A427F1DB5", want: true},
+		{a: "afe40693-8f63-4766-85f1-250a427f1db5", b: "afe40693-8f63-4766-85f1-250a427f1db5", want: true},
+		{a: Max, b: "FFFFFFFF-FFFF-FFFF-FFFF-FFFFFFFFFFFF", want: true},
+		{a: "afe40693-8f63-4766-85f1-250a427f1db5", b: "afe40693-8f63-4766-85f1-250a427f1db6", want: false},
+		{a: Nil, b: "afe40693-8f63-4766-85f1-250a427f1db5", want: false},
+		{a: "asda", b: "asda", want: false},
+		{a: "asda", b: Nil, want: false},
+		{a: "afe406938f63476685f1250a427f1db5", b: "afe40693-8f63-4766-85f1-250a427f1db5", want: false},
+	} {
+		if got := Equal(data.a, data.b); got != data.want {
+			t.Errorf("Equal(%v, %v) want: %v, got: %v", data.a, data.b, data.want, got)
+		}
+		if got := data.b.Equal(data.a); got != data.want {
+			t.Errorf("%v.Equal(%v) want: %v, got: %v", data.b, data.a, data.want, got)
+		}
+	}
+}
+
+func TestCommonPrefixBits(t *testing.T) {
+	for _, data := range []struct {
+		a, b UUID
+		want int
+	}{
+		{a: "afe40693-8f63-4766-85f1-250a427f1db5", b: "afe40693-8f63-4766-85f1-250a427f1db5", want: 128},
+		{a: "afe40693-8f63-4766-85f1-250a427f1db5", b: "AFE40693-8F63-4766-85F1-250A427F1DB5", want: 128},
+		{a: "afe40693-8f63-4766-85f1-250a427f1db5", b: "2fe40693-8f63-4766-85f1-250a427f1db5", want: 0},
+		{a: "afe40693-8f63-4766-85f1-250a427f1db5", b: "afe40693-8f63-4766-85f1-250a427f1db4", want: 127},
+		// byte boundaries
+		{a: "afe40693-8f63-4766-85f1-250a427f1db5", b: "af640693-8f63-4766-85f1-250a427f1db5", want: 8},
+		{a: "afe40693-8f63-4766-85f1-250a427f1db5", b: "afe40693-8f63-4766-05f1-250a427f1db5", want: 64},
+		// mid-byte
+		{a: "afe40693-8f63-4766-85f1-250a427f1db5", b: "afc40693-8f63-4766-85f1-250a427f1db5", want: 10},
+		{a: "afe40693-8f63-4766-85f1-250a427f1db5", b: "afe40693-8f63-4766-8571-250a427f1db5", want: 72},
+		{a: "afe40693-8f63-4766-85f1-250a427f1db5", b: "afe40693-8f63-4766-85f1-250a427f1d35", want: 120},
+	} {
+		got, err := CommonPrefixBits(data.a, data.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != data.want {
+			t.Errorf("CommonPrefixBits(%v, %v) want: %v, got: %v", data.a, data.b, data.want, got)
+		}
+	}
+
+	for _, data := range []struct {
+		name string
+		a, b UUID
+	}{
+		{name: "nil left", a: Nil, b: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{name: "nil right", a: "afe40693-8f63-4766-85f1-250a427f1db5", b: Nil},
+		{name: "malformed", a: "afe40693-8f63-4766-85f1-250a427f1db5", b: "asda"},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			if _, err := CommonPrefixBits(data.a, data.b); err == nil {
+				t.Errorf("expected error, but got nothing for %v, %v", data.a, data.b)
+			}
+		})
+	}
+}
+
+func TestDistance(t *testing.T) {
+	for _, data := range []struct {
+		a, b        UUID
+		want        string
+		wantHamming int
+	}{
+		{a: "afe40693-8f63-4766-85f1-250a427f1db5", b: "AFE40693-8F63-4766-85F1-250A427F1DB5", want: "0", wantHamming: 0},
+		{a: "00000000-0000-4000-8000-000000000001", b: "00000000-0000-4000-8000-000000000005", want: "4", wantHamming: 1},
+		{a: "00000000-0000-4000-8000-000000000005", b: "00000000-0000-4000-8000-000000000001", want: "4", wantHamming: 1},
+		{a: "00000000-0000-4000-8000-0000000000ff", b: "00000000-0000-4000-8000-000000000100", want: "1", wantHamming: 9},
+		// a uuid and its Next()
+		{a: "afe40693-8f63-4766-85f1-250a427f1db5", b: "dca97cf2-e0f5-477f-8529-4c98ed7564ce", want: "59511315171921118629115152358836684569", wantHamming: 60},
+		{a: "00000000-0000-4000-8000-000000000001", b: "2cc5765f-5192-4018-8038-278eaaf6471a", want: "59511315171921118610740465879165060889", wantHamming: 55},
+		{a: "00000000-0000-4000-8000-000000000000", b: Max, want: "340282366920938161222696331737619759103", wantHamming: 126},
+	} {
+		got, err := Distance(data.a, data.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != data.want {
+			t.Errorf("Distance(%v, %v) want: %v, got: %v", data.a, data.b, data.want, got)
+		}
+
+		hamming, err := HammingDistance(data.a, data.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hamming != data.wantHamming {
+			t.Errorf("HammingDistance(%v, %v) want: %v, got: %v", data.a, data.b, data.wantHamming, hamming)
+		}
+	}
+
+	for _, data := range []struct {
+		name string
+		a, b UUID
+	}{
+		{name: "nil left", a: Nil, b: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{name: "nil right", a: "afe40693-8f63-4766-85f1-250a427f1db5", b: Nil},
+		{name: "malformed", a: "asda", b: "afe40693-8f63-4766-85f1-250a427f1db5"},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			if _, err := Distance(data.a, data.b); err == nil {
+				t.Errorf("expected error, but got nothing for %v, %v", data.a, data.b)
+			}
+			if _, err := HammingDistance(data.a, data.b); err == nil {
+				t.Errorf("expected error, but got nothing for %v, %v", data.a, data.b)
+			}
+		})
+	}
+}
@@ -0,0 +1,325 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ToULIDString returns the uuid as a ULID string, reinterpreting its 16 bytes, eg: 5FWG3973V38XK8BW951917Y7DN
+// For time uuids (see NewTime) the timestamp of the ULID is the same as the one returned by TimeUUIDToTime.
+// Returns an empty string for Nil.
+func (u UUID) ToULIDString() (string, error) {
+	if u == Nil {
+		return "", nil
+	}
+
+	b, err := u.decode()
+	if err != nil {
+		return "", errInvalid("invalid uuid: " + u.String())
+	}
+
+	return encodeCrockford(b), nil
+}
+
+// FromULIDString converts a ULID string into a uuid, reinterpreting its 16 bytes.
+// The timestamp of the ULID is kept, so TimeUUIDToTime returns the same time as the ULID.
+// @warning - The conversion is lossy: ULIDs have no version and variant bits, so 7 bits of the ULID's entropy
+// are overwritten by the version, variant and time marker bits as in NewTime, converting it back results
+// in a different ULID.
+func FromULIDString(str string) (UUID, error) {
+	if len(str) != 26 {
+		return Nil, errInvalid("invalid ulid, must be 26 characters long: " + str)
+	}
+
+	b, err := decodeCrockford(str, "ulid")
+	if err != nil {
+		return Nil, err
+	}
+
+	setVersion(b[:])
+	b[8] |= timeMarker
+
+	return UUID(string(encodeBytes(b[:]))), nil
+}
+
+const (
+	base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	ksuidEpoch     = 1400000000
+	ksuidLength    = 27
+)
+
+// FromKSUID converts a KSUID string into a time uuid (see NewTime), eg: 0ujtsYcgvSTl8PAuAdqWYSMnLOv
+// The KSUID timestamp (seconds since 2014-05-13T16:53:20Z) is converted to milliseconds since the unix epoch,
+// so TimeUUIDToTime returns the time of the KSUID.
+// @warning - The conversion is lossy: only the first 10 bytes of the 16 bytes payload are kept,
+// and 7 bits of those are overwritten by the version, variant and time marker bits as in NewTime.
+func FromKSUID(str string) (UUID, error) {
+	if len(str) != ksuidLength {
+		return Nil, errInvalid("invalid ksuid, must be 27 characters long: " + str)
+	}
+
+	n := new(big.Int)
+	base := big.NewInt(62)
+	for i := 0; i < len(str); i++ {
+		digit := strings.IndexByte(base62Alphabet, str[i])
+		if digit < 0 {
+			return Nil, errInvalid("invalid ksuid, invalid character: " + str)
+		}
+		n.Mul(n, base)
+		n.Add(n, big.NewInt(int64(digit)))
+	}
+
+	if n.BitLen() > 160 {
+		return Nil, errInvalid("invalid ksuid, longer than 160 bits: " + str)
+	}
+
+	k := [20]byte{}
+	n.FillBytes(k[:])
+
+	ms := (uint64(binary.BigEndian.Uint32(k[0:4])) + ksuidEpoch) * 1000
+
+	u := [size]byte{}
+	u[0] = byte(ms >> 40)
+	u[1] = byte(ms >> 32)
+	u[2] = byte(ms >> 24)
+	u[3] = byte(ms >> 16)
+	u[4] = byte(ms >> 8)
+	u[5] = byte(ms)
+	copy(u[6:], k[4:14])
+	setVersion(u[:])
+	u[8] |= timeMarker
+
+	return UUID(string(encodeBytes(u[:]))), nil
+}
+
+// ToKSUID converts a time uuid (see NewTime) into a KSUID string, eg: 0ujtsVDLfekQc9yjQWzMIzwOwkq
+// Returns an empty string for Nil and an error if the timestamp is outside of the range KSUIDs can represent.
+// @warning - The conversion is lossy: the timestamp is truncated to seconds, the payload is the last 10 bytes
+// of the uuid padded with zeros.
+func (u UUID) ToKSUID() (string, error) {
+	if u == Nil {
+		return "", nil
+	}
+
+	b, err := u.decode()
+	if err != nil {
+		return "", errInvalid("invalid uuid: " + u.String())
+	}
+
+	ms := uint64(b[5]) | uint64(b[4])<<8 |
+		uint64(b[3])<<16 | uint64(b[2])<<24 |
+		uint64(b[1])<<32 | uint64(b[0])<<40
+	s := ms / 1000
+	if s < ksuidEpoch || s-ksuidEpoch > 0xffffffff {
+		return "", errors.New("uuid timestamp out of ksuid range: " + u.String())
+	}
+
+	k := [20]byte{}
+	binary.BigEndian.PutUint32(k[0:4], uint32(s-ksuidEpoch))
+	copy(k[4:14], b[6:16])
+
+	n := new(big.Int).SetBytes(k[:])
+	base := big.NewInt(62)
+	mod := new(big.Int)
+
+	res := make([]byte, ksuidLength)
+	for i := len(res) - 1; i >= 0; i-- {
+		n.DivMod(n, base, mod)
+		res[i] = base62Alphabet[mod.Int64()]
+	}
+
+	return string(res), nil
+}
+
+// WindowsBytes returns the 16 bytes of the uuid in the order used by .NET Guid.ToByteArray() and SQL Server:
+// the first three groups (4, 2 and 2 bytes) are little-endian, the last 8 bytes are as is.
+// Returns nil for Nil.
+func (u UUID) WindowsBytes() ([]byte, error) {
+	if u == Nil {
+		return nil, nil
+	}
+
+	b, err := u.decode()
+	if err != nil {
+		return nil, errInvalid("invalid uuid: " + u.String())
+	}
+
+	swapWindowsBytes(&b)
+
+	return b[:], nil
+}
+
+// FromWindowsBytes converts 16 bytes in the order used by .NET Guid.ToByteArray() and SQL Server into a uuid,
+// see WindowsBytes. The uuid is validated as in FromString, empty input results in Nil.
+func FromWindowsBytes(b []byte) (UUID, error) {
+	if len(b) == 0 {
+		return Nil, nil
+	}
+
+	if len(b) != size {
+		return Nil, errInvalid("invalid windows uuid bytes, must be 16 bytes long, got: " + strconv.Itoa(len(b)))
+	}
+
+	var ba [size]byte
+	copy(ba[:], b)
+	swapWindowsBytes(&ba)
+
+	return fromRaw(ba[:])
+}
+
+// swapWindowsBytes converts between big-endian and .NET byte order, the swap is its own inverse.
+func swapWindowsBytes(b *[size]byte) {
+	b[0], b[1], b[2], b[3] = b[3], b[2], b[1], b[0]
+	b[4], b[5] = b[5], b[4]
+	b[6], b[7] = b[7], b[6]
+}
+
+// Uint64Pair returns the uuid as two 64 bits integers: hi holds bytes 0-7 and lo holds bytes 8-15,
+// both interpreted as big-endian. Returns (0, 0) for Nil.
+func (u UUID) Uint64Pair() (hi uint64, lo uint64, err error) {
+	if u == Nil {
+		return 0, 0, nil
+	}
+
+	b, err := u.decode()
+	if err != nil {
+		return 0, 0, errInvalid("invalid uuid: " + u.String())
+	}
+
+	return binary.BigEndian.Uint64(b[0:8]), binary.BigEndian.Uint64(b[8:16]), nil
+}
+
+// FromUint64Pair converts two 64 bits integers into a uuid, the reverse of Uint64Pair.
+// The uuid is validated as in FromString, (0, 0) results in Nil.
+func FromUint64Pair(hi uint64, lo uint64) (UUID, error) {
+	b := [size]byte{}
+	binary.BigEndian.PutUint64(b[0:8], hi)
+	binary.BigEndian.PutUint64(b[8:16], lo)
+
+	return fromRaw(b[:])
+}
+
+// FromUint64PairLenient is like FromUint64Pair, but accepts any version and variant bits as LenientFromString.
+func FromUint64PairLenient(hi uint64, lo uint64) UUID {
+	if hi == 0 && lo == 0 {
+		return Nil
+	}
+
+	b := [size]byte{}
+	binary.BigEndian.PutUint64(b[0:8], hi)
+	binary.BigEndian.PutUint64(b[8:16], lo)
+
+	return UUID(string(encodeBytes(b[:])))
+}
+
+// BigInt returns the uuid as a 128 bits big-endian unsigned integer, the same as Python's uuid.UUID.int.
+// Returns 0 for Nil and nil for malformed uuids.
+func (u UUID) BigInt() *big.Int {
+	if u == Nil {
+		return new(big.Int)
+	}
+
+	b, err := u.decode()
+	if err != nil {
+		return nil
+	}
+
+	return new(big.Int).SetBytes(b[:])
+}
+
+// FromBigInt converts a 128 bits unsigned integer into a uuid, the reverse of BigInt.
+// The uuid is validated as in FromString, 0 results in Nil.
+func FromBigInt(i *big.Int) (UUID, error) {
+	if i == nil {
+		return Nil, errInvalid("invalid uuid integer: nil")
+	}
+
+	if i.Sign() < 0 {
+		return Nil, errInvalid("invalid uuid integer, negative: " + i.String())
+	}
+
+	if i.BitLen() > 128 {
+		return Nil, errInvalid("invalid uuid integer, longer than 128 bits: " + i.String())
+	}
+
+	b := [size]byte{}
+	i.FillBytes(b[:])
+
+	return fromRaw(b[:])
+}
+
+// FromTraceID converts a 16 bytes trace id (eg: OpenTelemetry's trace.TraceID) into a uuid, keeping all bytes as is.
+// The zero trace id results in Nil, see the otel subpackage for converting a trace.SpanContext.
+// @warning - Trace ids are random bytes without version and variant bits, so the result is accepted by
+// LenientFromString, but usually not by FromString. The bits are not overwritten, so TraceID returns the same id.
+// Marshalling it fails unless disabled with SetMarshalValidation, store it with String or Bytes instead.
+func FromTraceID(id [16]byte) UUID {
+	if id == [size]byte{} {
+		return Nil
+	}
+
+	return UUID(string(encodeBytes(id[:])))
+}
+
+// TraceID returns the 16 bytes of the uuid as a trace id, the reverse of FromTraceID.
+// Returns the zero (invalid) trace id for Nil.
+func (u UUID) TraceID() ([16]byte, error) {
+	if u == Nil {
+		return [size]byte{}, nil
+	}
+
+	b, err := u.decode()
+	if err != nil {
+		return [size]byte{}, errInvalid("invalid uuid: " + u.String())
+	}
+
+	return b, nil
+}
+
+// snowflakeMarker is stored in the last 6 bytes of uuids created by FromSnowflake ("snowfl" in ASCII).
+var snowflakeMarker = [6]byte{'s', 'n', 'o', 'w', 'f', 'l'}
+
+// FromSnowflake embeds a 63 bits Snowflake id (41 bits timestamp, 10 bits worker id, 12 bits sequence) into
+// a version 4 uuid, eg: 00559684-7d88-4000-817a-736e6f77666c
+// Layout: the timestamp in the first 48 bits (the top 7 bits are zero), the version, the sequence in 12 bits,
+// the variant, 4 zero bits, the worker id in 10 bits and a fixed marker in the last 48 bits.
+// Uuids created from Snowflake ids are sorted by their timestamp. Negative ids result in Nil.
+func FromSnowflake(id int64) UUID {
+	if id < 0 {
+		return Nil
+	}
+
+	timestamp := uint64(id) >> 22
+	worker := uint16(id>>12) & 0x3ff
+	sequence := uint16(id) & 0xfff
+
+	b := [size]byte{}
+	binary.BigEndian.PutUint64(b[0:8], timestamp<<16)
+	binary.BigEndian.PutUint16(b[6:8], 0x4000|sequence)
+	binary.BigEndian.PutUint16(b[8:10], 0x8000|worker)
+	copy(b[10:], snowflakeMarker[:])
+
+	return UUID(string(encodeBytes(b[:])))
+}
+
+// ToSnowflake returns the Snowflake id embedded in the uuid by FromSnowflake.
+// Returns an error if the uuid was not created by FromSnowflake, including Nil.
+func ToSnowflake(u UUID) (int64, error) {
+	b, err := u.decode()
+	if err != nil {
+		return 0, errInvalid("invalid uuid: " + u.String())
+	}
+
+	if b[0]&0xfe != 0 || b[6]&0xf0 != 0x40 || b[8]&0xfc != 0x80 || [6]byte(b[10:]) != snowflakeMarker {
+		return 0, errors.New("not a snowflake uuid: " + u.String())
+	}
+
+	timestamp := binary.BigEndian.Uint64(b[0:8]) >> 16
+	sequence := binary.BigEndian.Uint16(b[6:8]) & 0xfff
+	worker := binary.BigEndian.Uint16(b[8:10]) & 0x3ff
+
+	return int64(timestamp<<22 | uint64(worker)<<12 | uint64(sequence)), nil
+}
@@ -0,0 +1,546 @@
+package uuid
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestToULIDString(t *testing.T) {
+	for _, data := range []struct {
+		original UUID
+		want     string
+		back     UUID
+	}{
+		{original: "", want: ""},
+		// the time marker is set on the way back
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", want: "5FWG3973V38XK8BW951917Y7DN", back: "afe40693-8f63-4766-a5f1-250a427f1db5"},
+		{original: "01563e3a-b5d3-4676-ac61-efb99302bd5b", want: "01ARZ3NDEK8SVARRFFQ69G5FAV", back: "01563e3a-b5d3-4676-ac61-efb99302bd5b"},
+	} {
+		got, err := data.original.ToULIDString()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data.want != got {
+			t.Errorf("want: %s, got: %s", data.want, got)
+		}
+
+		if data.original == Nil {
+			continue
+		}
+
+		// time uuids survive the round trip
+		back, err := FromULIDString(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back != data.back {
+			t.Errorf("want: %s, got: %s", data.back, back)
+		}
+	}
+
+	if _, err := UUID("asda").ToULIDString(); err == nil {
+		t.Error("expected error, but got nothing for malformed uuid")
+	}
+}
+
+func TestFromULIDString(t *testing.T) {
+	// ulid from the spec, its entropy does not contain the version and variant bits
+	const ulid = "01ARZ3NDEKTSV4RRFFQ69G5FAV"
+
+	got, err := FromULIDString(ulid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := UUID("01563e3a-b5d3-4676-ac61-efb99302bd5b"); want != got {
+		t.Errorf("want: %s, got: %s", want, got)
+	}
+
+	if _, err := FromString(got.String()); err != nil {
+		t.Error(err)
+	}
+
+	tm, err := got.TimeUUIDToTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.UnixMilli(1469922850259).UTC(); !want.Equal(tm) {
+		t.Errorf("want: %v, got: %v", want, tm)
+	}
+
+	// lossy: the version and variant bits are overwritten
+	back, err := got.ToULIDString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back == ulid {
+		t.Errorf("expected different ulid, got: %s", back)
+	}
+	if back[:10] != ulid[:10] {
+		t.Errorf("timestamp part is different, want: %s, got: %s", ulid[:10], back[:10])
+	}
+}
+
+func TestULIDTime(t *testing.T) {
+	tm := time.Date(2024, 1, 2, 3, 4, 5, 6000000, time.UTC)
+
+	ulid, err := NewTime(tm).ToULIDString()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	u, err := FromULIDString(ulid)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := u.TimeUUIDToTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tm.Equal(got) {
+		t.Errorf("want: %v, got: %v", tm, got)
+	}
+}
+
+func TestFromULIDStringError(t *testing.T) {
+	for _, data := range []struct {
+		name     string
+		original string
+	}{
+		{name: "empty", original: ""},
+		{name: "too short", original: "01ARZ3NDEKTSV4RRFFQ69G5FA"},
+		{name: "too long", original: "01ARZ3NDEKTSV4RRFFQ69G5FAVV"},
+		{name: "overflow", original: "81ARZ3NDEKTSV4RRFFQ69G5FAV"},
+		{name: "invalid character", original: "01ARZ3NDEKTSV4RRFFQ69G5FAU"},
+		{name: "hyphen", original: "01ARZ3NDEK-SV4RRFFQ69G5FAV"},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			_, err := FromULIDString(data.original)
+			if err == nil {
+				t.Errorf("expected error, but got nothing for %v", data.original)
+			}
+		})
+	}
+}
+
+func TestFromKSUID(t *testing.T) {
+	for _, data := range []struct {
+		original string
+		want     UUID
+		wantTime time.Time
+	}{
+		{
+			// example from github.com/segmentio/ksuid
+			original: "0ujtsYcgvSTl8PAuAdqWYSMnLOv",
+			want:     "015f0471-2d98-45a1-ad34-b5f99d1154fb",
+			wantTime: time.Date(2017, 10, 10, 4, 0, 47, 0, time.UTC),
+		},
+		{
+			original: "000000000000000000000000000",
+			want:     "0145f680-b000-4000-a000-000000000000",
+			wantTime: time.Date(2014, 5, 13, 16, 53, 20, 0, time.UTC),
+		},
+		{
+			original: "aWgEPTl1tmebfsQzFP4bxwgy80V",
+			want:     "052df680-ac18-4fff-bfff-ffffffffffff",
+			wantTime: time.Date(2150, 6, 19, 23, 21, 35, 0, time.UTC),
+		},
+	} {
+		got, err := FromKSUID(data.original)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data.want != got {
+			t.Errorf("want: %s, got: %s", data.want, got)
+		}
+
+		if _, err := FromString(got.String()); err != nil {
+			t.Error(err)
+		}
+
+		tm, err := got.TimeUUIDToTime()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !data.wantTime.Equal(tm) {
+			t.Errorf("want: %v, got: %v", data.wantTime, tm)
+		}
+	}
+}
+
+func TestFromKSUIDError(t *testing.T) {
+	for _, data := range []struct {
+		name     string
+		original string
+	}{
+		{name: "empty", original: ""},
+		{name: "too short", original: "0ujtsYcgvSTl8PAuAdqWYSMnLO"},
+		{name: "too long", original: "0ujtsYcgvSTl8PAuAdqWYSMnLOvv"},
+		{name: "invalid character", original: "0ujtsYcgvSTl8PAuAdqWYSMnLO-"},
+		{name: "longer than 160 bits", original: "aWgEPTl1tmebfsQzFP4bxwgy80W"},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			_, err := FromKSUID(data.original)
+			if err == nil {
+				t.Errorf("expected error, but got nothing for %v", data.original)
+			}
+		})
+	}
+}
+
+func TestToKSUID(t *testing.T) {
+	got, err := UUID("015f0471-2d98-45a1-8d34-b5f99d1154fb").ToKSUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "0ujtsVDLfekQc9yjQWzMIzwOwkq"; want != got {
+		t.Errorf("want: %s, got: %s", want, got)
+	}
+
+	if got, err := Nil.ToKSUID(); err != nil || got != "" {
+		t.Errorf("want empty string for Nil, got: %v, %v", got, err)
+	}
+
+	// uuids with whole second timestamps survive the round trip
+	u := NewTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	ksuid, err := u.ToKSUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	back, err := FromKSUID(ksuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back != u {
+		t.Errorf("want: %s, got: %s", u, back)
+	}
+}
+
+func TestToKSUIDError(t *testing.T) {
+	for _, u := range []UUID{
+		NewTime(time.Date(2014, 5, 13, 16, 53, 19, 999000000, time.UTC)),
+		NewTime(time.Date(2150, 6, 19, 23, 21, 36, 0, time.UTC)),
+		"asda",
+	} {
+		if _, err := u.ToKSUID(); err == nil {
+			t.Errorf("expected error, but got nothing for %v", u)
+		}
+	}
+}
+
+func TestWindowsBytes(t *testing.T) {
+	for _, data := range []struct {
+		original UUID
+		want     []byte
+	}{
+		{
+			original: "",
+			want:     nil,
+		},
+		{
+			// new Guid("35918bc9-196d-40ea-9779-889d79b753f0").ToByteArray(), example of the .NET documentation
+			original: "35918bc9-196d-40ea-9779-889d79b753f0",
+			want:     []byte{0xc9, 0x8b, 0x91, 0x35, 0x6d, 0x19, 0xea, 0x40, 0x97, 0x79, 0x88, 0x9d, 0x79, 0xb7, 0x53, 0xf0},
+		},
+		{
+			original: "afe40693-8f63-4766-85f1-250a427f1db5",
+			want:     []byte{0x93, 0x06, 0xe4, 0xaf, 0x63, 0x8f, 0x66, 0x47, 0x85, 0xf1, 0x25, 0x0a, 0x42, 0x7f, 0x1d, 0xb5},
+		},
+	} {
+		got, err := data.original.WindowsBytes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(data.want, got) {
+			t.Errorf("want: %x, got: %x", data.want, got)
+		}
+
+		back, err := FromWindowsBytes(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back != data.original {
+			t.Errorf("want: %s, got: %s", data.original, back)
+		}
+	}
+
+	if _, err := UUID("asda").WindowsBytes(); err == nil {
+		t.Error("expected error, but got nothing for malformed uuid")
+	}
+}
+
+func TestFromWindowsBytesError(t *testing.T) {
+	for _, data := range []struct {
+		name     string
+		original []byte
+	}{
+		{name: "too short", original: make([]byte, 15)},
+		{name: "too long", original: make([]byte, 17)},
+		// big-endian bytes of afe40693-8f63-4766-85f1-250a427f1db5 have an invalid version in windows order
+		{name: "big-endian bytes", original: []byte{0xaf, 0xe4, 0x06, 0x93, 0x8f, 0x63, 0x47, 0x66, 0x85, 0xf1, 0x25, 0x0a, 0x42, 0x7f, 0x1d, 0xb5}},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			_, err := FromWindowsBytes(data.original)
+			if err == nil {
+				t.Errorf("expected error, but got nothing for %x", data.original)
+			}
+		})
+	}
+}
+
+func TestUint64Pair(t *testing.T) {
+	for _, data := range []struct {
+		original UUID
+		wantHi   uint64
+		wantLo   uint64
+	}{
+		{original: "", wantHi: 0, wantLo: 0},
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", wantHi: 0xafe406938f634766, wantLo: 0x85f1250a427f1db5},
+		{original: Max, wantHi: 0xffffffffffffffff, wantLo: 0xffffffffffffffff},
+	} {
+		hi, lo, err := data.original.Uint64Pair()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data.wantHi != hi || data.wantLo != lo {
+			t.Errorf("want: %x %x, got: %x %x", data.wantHi, data.wantLo, hi, lo)
+		}
+
+		back, err := FromUint64Pair(hi, lo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back != data.original {
+			t.Errorf("want: %s, got: %s", data.original, back)
+		}
+	}
+
+	if _, _, err := UUID("asda").Uint64Pair(); err == nil {
+		t.Error("expected error, but got nothing for malformed uuid")
+	}
+}
+
+func TestUint64PairRandom(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		u := NewV4()
+
+		hi, lo, err := u.Uint64Pair()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		back, err := FromUint64Pair(hi, lo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back != u {
+			t.Fatalf("want: %s, got: %s", u, back)
+		}
+	}
+}
+
+func TestFromUint64PairLenient(t *testing.T) {
+	const hi, lo = 0x9999999999996999, 0x1999250a427f1db5
+
+	if _, err := FromUint64Pair(hi, lo); err == nil {
+		t.Error("expected error, but got nothing for invalid version and variant")
+	}
+
+	got := FromUint64PairLenient(hi, lo)
+	if want := UUID("99999999-9999-6999-1999-250a427f1db5"); want != got {
+		t.Errorf("want: %s, got: %s", want, got)
+	}
+
+	gotHi, gotLo, err := got.Uint64Pair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotHi != hi || gotLo != lo {
+		t.Errorf("want: %x %x, got: %x %x", uint64(hi), uint64(lo), gotHi, gotLo)
+	}
+
+	if got := FromUint64PairLenient(0, 0); got != Nil {
+		t.Errorf("want: Nil, got: %s", got)
+	}
+}
+
+func TestBigInt(t *testing.T) {
+	for _, data := range []struct {
+		original UUID
+		want     string
+	}{
+		{original: "", want: "0"},
+		// python: uuid.UUID("afe40693-8f63-4766-85f1-250a427f1db5").int
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", want: "233798876331480107204185267228142149045"},
+		{original: Max, want: "340282366920938463463374607431768211455"},
+	} {
+		got := data.original.BigInt()
+		if got.String() != data.want {
+			t.Errorf("want: %s, got: %s", data.want, got)
+		}
+
+		back, err := FromBigInt(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back != data.original {
+			t.Errorf("want: %s, got: %s", data.original, back)
+		}
+	}
+
+	if got := UUID("asda").BigInt(); got != nil {
+		t.Errorf("want nil for malformed uuid, got: %v", got)
+	}
+}
+
+func TestFromBigIntError(t *testing.T) {
+	tooBig := new(big.Int).Lsh(big.NewInt(1), 128)
+	invalid, _ := new(big.Int).SetString("99999999999969999999250a427f1db5", 16)
+
+	for _, data := range []struct {
+		name     string
+		original *big.Int
+	}{
+		{name: "nil", original: nil},
+		{name: "negative", original: big.NewInt(-1)},
+		{name: "longer than 128 bits", original: tooBig},
+		{name: "invalid version bit", original: invalid},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			_, err := FromBigInt(data.original)
+			if err == nil {
+				t.Errorf("expected error, but got nothing for %v", data.original)
+			}
+		})
+	}
+}
+
+func TestTraceID(t *testing.T) {
+	for _, data := range []struct {
+		id   [16]byte
+		want UUID
+	}{
+		{
+			id:   [16]byte{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+			want: "4bf92f35-77b3-4da6-a3ce-929d0e0e4736",
+		},
+		{
+			// version and variant bits are kept as is
+			id:   [16]byte{0x0a, 0xf7, 0x65, 0x19, 0x16, 0xcd, 0x43, 0xdd, 0x84, 0x48, 0xeb, 0x21, 0x1c, 0x80, 0x31, 0x9c},
+			want: "0af76519-16cd-43dd-8448-eb211c80319c",
+		},
+		{
+			id:   [16]byte{0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0, 0x12, 0x34, 0x56, 0x78, 0x9a, 0xbc, 0xde, 0xf0},
+			want: "12345678-9abc-def0-1234-56789abcdef0",
+		},
+		{
+			id:   [16]byte{},
+			want: Nil,
+		},
+	} {
+		got := FromTraceID(data.id)
+		if got != data.want {
+			t.Errorf("want: %s, got: %s", data.want, got)
+		}
+
+		back, err := got.TraceID()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back != data.id {
+			t.Errorf("want: %x, got: %x", data.id, back)
+		}
+	}
+
+	if _, err := UUID("asda").TraceID(); err == nil {
+		t.Error("expected error, but got nothing for malformed uuid")
+	}
+}
+
+func TestSnowflake(t *testing.T) {
+	for _, data := range []struct {
+		id   int64
+		want UUID
+	}{
+		{id: 1541815603606036480, want: "00559684-7d88-4000-817a-736e6f77666c"},
+		{id: 0, want: "00000000-0000-4000-8000-736e6f77666c"},
+		{id: 1<<63 - 1, want: "01ffffff-ffff-4fff-83ff-736e6f77666c"},
+		{id: 4095, want: "00000000-0000-4fff-8000-736e6f77666c"},
+		{id: 4096, want: "00000000-0000-4000-8001-736e6f77666c"},
+		{id: 1<<22 - 1, want: "00000000-0000-4fff-83ff-736e6f77666c"},
+		{id: 1 << 22, want: "00000000-0001-4000-8000-736e6f77666c"},
+	} {
+		got := FromSnowflake(data.id)
+		if got != data.want {
+			t.Errorf("want: %s, got: %s", data.want, got)
+		}
+		if _, err := FromString(got.String()); err != nil {
+			t.Error(err)
+		}
+
+		back, err := ToSnowflake(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back != data.id {
+			t.Errorf("want: %v, got: %v", data.id, back)
+		}
+	}
+
+	if got := FromSnowflake(-1); got != Nil {
+		t.Errorf("want: Nil, got: %s", got)
+	}
+}
+
+func TestSnowflakeRoundTrip(t *testing.T) {
+	const timestamp = int64(1288834974657)
+	for worker := int64(0); worker < 1024; worker += 341 {
+		// sequence rollover: the last sequence of one millisecond and the first of the next one
+		for _, id := range []int64{
+			timestamp<<22 | worker<<12 | 4094,
+			timestamp<<22 | worker<<12 | 4095,
+			(timestamp+1)<<22 | worker<<12,
+			(timestamp+1)<<22 | worker<<12 | 1,
+		} {
+			u := FromSnowflake(id)
+			back, err := ToSnowflake(u)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if back != id {
+				t.Errorf("want: %v, got: %v", id, back)
+			}
+		}
+	}
+
+	prev := FromSnowflake(timestamp<<22 | 4095)
+	next := FromSnowflake((timestamp + 1) << 22)
+	if prev >= next {
+		t.Errorf("expected %s to be sorted before %s", prev, next)
+	}
+}
+
+func TestToSnowflakeError(t *testing.T) {
+	for _, data := range []struct {
+		name     string
+		original UUID
+	}{
+		{name: "nil", original: Nil},
+		{name: "malformed", original: "asda"},
+		{name: "random", original: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{name: "timestamp too large", original: "02000000-0000-4000-8000-736e6f77666c"},
+		{name: "wrong version", original: "00000000-0000-1000-8000-736e6f77666c"},
+		{name: "non-zero padding", original: "00000000-0000-4000-8400-736e6f77666c"},
+		{name: "wrong marker", original: "00000000-0000-4000-8000-736e6f77666d"},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			_, err := ToSnowflake(data.original)
+			if err == nil {
+				t.Errorf("expected error, but got nothing for %v", data.original)
+			}
+		})
+	}
+}
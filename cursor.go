@@ -0,0 +1,131 @@
+package uuid
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io"
+	"sync/atomic"
+)
+
+// cursorMACSize is the length of the truncated HMAC-SHA256 appended to cursors.
+const cursorMACSize = 8
+
+// cursorKey is the key of the cursor HMAC, see SetCursorKey.
+var cursorKey atomic.Pointer[[]byte]
+
+func init() {
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		panic(err)
+	}
+	cursorKey.Store(&key)
+}
+
+// SetCursorKey sets the key used to sign and verify cursors, see EncodeCursor. It returns an error for an empty key.
+// By default a random key is generated on startup, so cursors are only accepted by the same process:
+// set the same key on every instance for cursors which survive restarts and load balancing.
+func SetCursorKey(key []byte) error {
+	if len(key) == 0 {
+		return errors.New("empty cursor key")
+	}
+
+	k := append([]byte(nil), key...)
+	cursorKey.Store(&k)
+
+	return nil
+}
+
+// EncodeCursor returns an opaque, url-safe pagination cursor holding u, 32 characters long.
+// It is the unpadded base64url encoding of the 16 bytes of u followed by an 8 bytes long HMAC-SHA256
+// signature, so DecodeCursor rejects tampered cursors. Returns an empty string for Nil and malformed uuids.
+// @warning - Cursors are signed, not encrypted: u can be read from the cursor.
+func EncodeCursor(u UUID) string {
+	return encodeCursor(u)
+}
+
+// DecodeCursor returns the uuid of a cursor created by EncodeCursor, validated as in FromString.
+// Returns Nil for the empty string and an error for truncated, tampered and otherwise invalid cursors.
+func DecodeCursor(str string) (UUID, error) {
+	uuids, err := decodeCursor(str, 1)
+	if err != nil || uuids == nil {
+		return Nil, err
+	}
+
+	return uuids[0], nil
+}
+
+// EncodeCursor2 is like EncodeCursor, but holds two uuids, eg: a time uuid and an id for keyset pagination.
+// Returns an empty string if any of them is Nil or malformed.
+func EncodeCursor2(a, b UUID) string {
+	return encodeCursor(a, b)
+}
+
+// DecodeCursor2 returns the uuids of a cursor created by EncodeCursor2, see DecodeCursor.
+func DecodeCursor2(str string) (UUID, UUID, error) {
+	uuids, err := decodeCursor(str, 2)
+	if err != nil || uuids == nil {
+		return Nil, Nil, err
+	}
+
+	return uuids[0], uuids[1], nil
+}
+
+func encodeCursor(uuids ...UUID) string {
+	buf := make([]byte, 0, len(uuids)*size+cursorMACSize)
+	for _, u := range uuids {
+		if u == Nil {
+			return ""
+		}
+
+		b, err := u.decode()
+		if err != nil {
+			return ""
+		}
+		buf = append(buf, b[:]...)
+	}
+
+	buf = append(buf, cursorMAC(buf)...)
+
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// decodeCursor returns the n uuids of the cursor, nil for the empty string.
+func decodeCursor(str string, n int) ([]UUID, error) {
+	if str == "" {
+		return nil, nil
+	}
+
+	buf, err := base64.RawURLEncoding.Strict().DecodeString(str)
+	if err != nil || len(buf) != n*size+cursorMACSize {
+		return nil, errors.New("invalid cursor: " + str)
+	}
+
+	data, mac := buf[:n*size], buf[n*size:]
+	if !hmac.Equal(mac, cursorMAC(data)) {
+		return nil, errors.New("invalid cursor signature: " + str)
+	}
+
+	uuids := make([]UUID, n)
+	for i := range uuids {
+		u, err := fromRaw(data[i*size : (i+1)*size])
+		if err != nil {
+			return nil, err
+		}
+		uuids[i] = u
+	}
+
+	return uuids, nil
+}
+
+// cursorMAC returns the truncated HMAC-SHA256 of data, the uuids of the cursor.
+// The domain separates it from other HMACs computed with the same key.
+func cursorMAC(data []byte) []byte {
+	h := hmac.New(sha256.New, *cursorKey.Load())
+	_, _ = h.Write([]byte("uuid cursor"))
+	_, _ = h.Write(data)
+
+	return h.Sum(nil)[:cursorMACSize]
+}
@@ -0,0 +1,113 @@
+package uuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCursor(t *testing.T) {
+	if err := SetCursorKey([]byte("secret")); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, original := range []UUID{
+		"afe40693-8f63-4766-85f1-250a427f1db5",
+		"016d6c41-26bb-4766-a5f1-250a427f1db5",
+		Max,
+		NewTime(time.Now()),
+	} {
+		cursor := EncodeCursor(original)
+		if len(cursor) != 32 {
+			t.Errorf("want 32 characters long cursor, got: %v", cursor)
+		}
+
+		got, err := DecodeCursor(cursor)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != original {
+			t.Errorf("want: %v, got: %v", original, got)
+		}
+	}
+
+	// the cursor is stable for the same key
+	if want, got := "r-QGk49jR2aF8SUKQn8dtcZaUHU1w1-b", EncodeCursor("afe40693-8f63-4766-85f1-250a427f1db5"); want != got {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+
+	for _, original := range []UUID{Nil, "asda"} {
+		if got := EncodeCursor(original); got != "" {
+			t.Errorf("want empty string for %v, got: %v", original, got)
+		}
+	}
+
+	if got, err := DecodeCursor(""); err != nil || got != Nil {
+		t.Errorf("want: %v, got: %v, %v", Nil, got, err)
+	}
+}
+
+func TestCursor2(t *testing.T) {
+	a, b := NewTime(time.Now()), NewV4()
+
+	gotA, gotB, err := DecodeCursor2(EncodeCursor2(a, b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotA != a || gotB != b {
+		t.Errorf("want: %v, %v, got: %v, %v", a, b, gotA, gotB)
+	}
+
+	if got := EncodeCursor2(a, Nil); got != "" {
+		t.Errorf("want empty string, got: %v", got)
+	}
+
+	// single and composite cursors are not interchangeable
+	if _, err := DecodeCursor(EncodeCursor2(a, b)); err == nil {
+		t.Error("expected error, but got nothing for composite cursor")
+	}
+	if _, _, err := DecodeCursor2(EncodeCursor(a)); err == nil {
+		t.Error("expected error, but got nothing for single cursor")
+	}
+}
+
+func TestDecodeCursorError(t *testing.T) {
+	if err := SetCursorKey([]byte("secret")); err != nil {
+		t.Fatal(err)
+	}
+	valid := EncodeCursor("afe40693-8f63-4766-85f1-250a427f1db5")
+
+	// flip one bit of the uuid part
+	tampered := []byte(valid)
+	tampered[0] ^= 0x01
+
+	for _, data := range []struct {
+		name     string
+		original string
+	}{
+		{name: "truncated", original: valid[:31]},
+		{name: "truncated mac", original: valid[:30]},
+		{name: "uuid only", original: "r-QGk49jR2aF8SUKQn8dtQ"},
+		{name: "too long", original: valid + "AA"},
+		{name: "tampered", original: string(tampered)},
+		{name: "invalid character", original: valid[:31] + "="},
+		{name: "standard base64", original: "r+QGk49jR2aF8SUKQn8dtcZaUHU1w1+b"},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			if _, err := DecodeCursor(data.original); err == nil {
+				t.Errorf("expected error, but got nothing for %v", data.original)
+			}
+		})
+	}
+
+	// cursors signed with another key are rejected
+	if err := SetCursorKey([]byte("other")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DecodeCursor(valid); err == nil {
+		t.Errorf("expected error, but got nothing for %v", valid)
+	}
+
+	if err := SetCursorKey(nil); err == nil {
+		t.Error("expected error, but got nothing for empty key")
+	}
+}
@@ -0,0 +1,344 @@
+package uuid
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"math/big"
+	"strings"
+)
+
+// Short returns the uuid as 22 characters long unpadded base64url encoding of its bytes, eg: r-QGk49jR2aF8SUKQn8dtQ
+// Returns an empty string for Nil and malformed uuids.
+func (u UUID) Short() string {
+	if u == Nil {
+		return ""
+	}
+
+	b, err := u.decode()
+	if err != nil {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b[:])
+}
+
+// FromShort parses uuid in the format returned by Short, eg: r-QGk49jR2aF8SUKQn8dtQ
+// The decoded uuid is validated as in FromString.
+func FromShort(str string) (UUID, error) {
+	if str == "" {
+		return Nil, nil
+	}
+
+	if len(str) != 22 {
+		return Nil, errInvalid("invalid short uuid: " + str)
+	}
+
+	b, err := base64.RawURLEncoding.Strict().DecodeString(str)
+	if err != nil {
+		return Nil, errInvalid("invalid short uuid: " + str)
+	}
+
+	return fromRaw(b)
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// Base58 returns the uuid in base58 format using the Bitcoin alphabet, eg: NikCFgDe7RmG2a8p8nnnua
+// The bytes are interpreted as a big-endian number, every leading zero byte is encoded as '1'.
+// Returns an empty string for Nil and malformed uuids.
+func (u UUID) Base58() string {
+	if u == Nil {
+		return ""
+	}
+
+	b, err := u.decode()
+	if err != nil {
+		return ""
+	}
+
+	zeros := 0
+	for zeros < size && b[zeros] == 0 {
+		zeros++
+	}
+
+	n := new(big.Int).SetBytes(b[:])
+	base := big.NewInt(58)
+	mod := new(big.Int)
+
+	// 16 bytes need at most 22 digits
+	res := make([]byte, 0, 22)
+	for n.Sign() > 0 {
+		n.DivMod(n, base, mod)
+		res = append(res, base58Alphabet[mod.Int64()])
+	}
+	for i := 0; i < zeros; i++ {
+		res = append(res, '1')
+	}
+
+	// digits were added in reverse order
+	for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+		res[i], res[j] = res[j], res[i]
+	}
+
+	return string(res)
+}
+
+// FromBase58 parses uuid in the format returned by Base58, eg: NikCFgDe7RmG2a8p8nnnua
+// The decoded value must be exactly 16 bytes long and is validated as in FromString.
+func FromBase58(str string) (UUID, error) {
+	if str == "" {
+		return Nil, nil
+	}
+
+	if len(str) > 22 {
+		return Nil, errInvalid("invalid base58 uuid: " + str)
+	}
+
+	zeros := 0
+	for zeros < len(str) && str[zeros] == '1' {
+		zeros++
+	}
+
+	n := new(big.Int)
+	base := big.NewInt(58)
+	for i := 0; i < len(str); i++ {
+		digit := strings.IndexByte(base58Alphabet, str[i])
+		if digit < 0 {
+			return Nil, errInvalid("invalid base58 uuid, invalid character: " + str)
+		}
+		n.Mul(n, base)
+		n.Add(n, big.NewInt(int64(digit)))
+	}
+
+	if zeros+len(n.Bytes()) != size {
+		return Nil, errInvalid("invalid base58 uuid, must be 16 bytes long: " + str)
+	}
+
+	b := [size]byte{}
+	n.FillBytes(b[:])
+
+	return fromRaw(b[:])
+}
+
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// Base32Crockford returns the uuid in Crockford's base32 format, eg: 5FWG3973V38XK8BW951917Y7DN
+// The bytes are interpreted as a big-endian number, encoded in 26 characters.
+// Returns an empty string for Nil and malformed uuids.
+func (u UUID) Base32Crockford() string {
+	if u == Nil {
+		return ""
+	}
+
+	b, err := u.decode()
+	if err != nil {
+		return ""
+	}
+
+	return encodeCrockford(b)
+}
+
+// FromBase32Crockford parses uuid in Crockford's base32 format, eg: 5FWG-3973-V38X-K8BW-9519-17Y7-DN
+// It is case-insensitive, hyphens are ignored and the commonly confused I, L and O characters are read as 1 and 0.
+// The decoded uuid is validated as in FromString.
+func FromBase32Crockford(str string) (UUID, error) {
+	if str == "" {
+		return Nil, nil
+	}
+
+	b, err := decodeCrockford(str, "base32")
+	if err != nil {
+		return Nil, err
+	}
+
+	return fromRaw(b[:])
+}
+
+func encodeCrockford(b [size]byte) string {
+	hi := binary.BigEndian.Uint64(b[0:8])
+	lo := binary.BigEndian.Uint64(b[8:16])
+
+	res := make([]byte, 26)
+	for i := len(res) - 1; i >= 0; i-- {
+		res[i] = crockfordAlphabet[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+
+	return string(res)
+}
+
+// decodeCrockford decodes 26 Crockford's base32 digits into a 128 bits big-endian number, format is used in errors.
+func decodeCrockford(str string, format string) ([size]byte, error) {
+	b := [size]byte{}
+
+	var hi, lo uint64
+	digits := 0
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		if c == '-' {
+			continue
+		}
+
+		d := crockfordDigit(c)
+		if d < 0 {
+			return b, errInvalid("invalid " + format + " uuid, invalid character: " + str)
+		}
+
+		// the first digit can only hold the top 3 bits
+		if digits == 0 && d > 7 || digits == 26 {
+			return b, errInvalid("invalid " + format + " uuid, longer than 128 bits: " + str)
+		}
+		digits++
+
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(d)
+	}
+
+	if digits != 26 {
+		return b, errInvalid("invalid " + format + " uuid, must be 26 characters long: " + str)
+	}
+
+	binary.BigEndian.PutUint64(b[0:8], hi)
+	binary.BigEndian.PutUint64(b[8:16], lo)
+
+	return b, nil
+}
+
+func crockfordDigit(c byte) int {
+	if 'a' <= c && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+
+	switch c {
+	case 'I', 'L':
+		return 1
+	case 'O':
+		return 0
+	}
+
+	return strings.IndexByte(crockfordAlphabet, c)
+}
+
+var sortableEncoding = base32.HexEncoding.WithPadding(base32.NoPadding)
+
+// SortableString returns the uuid in 26 characters long unpadded base32hex format, eg: LVI0D4SFCD3MD1FH4K544VOTMK
+// Unlike the canonical format it is denser, but it preserves the same order:
+// a.String() < b.String() if and only if a.SortableString() < b.SortableString().
+// Returns an empty string for Nil and malformed uuids.
+func (u UUID) SortableString() string {
+	if u == Nil {
+		return ""
+	}
+
+	b, err := u.decode()
+	if err != nil {
+		return ""
+	}
+
+	return sortableEncoding.EncodeToString(b[:])
+}
+
+// FromSortableString parses uuid in the format returned by SortableString, eg: LVI0D4SFCD3MD1FH4K544VOTMK
+// The decoded uuid is validated as in FromString.
+func FromSortableString(str string) (UUID, error) {
+	if str == "" {
+		return Nil, nil
+	}
+
+	if len(str) != 26 {
+		return Nil, errInvalid("invalid sortable uuid: " + str)
+	}
+
+	b, err := sortableEncoding.DecodeString(str)
+	// the last character holds 2 unused bits, they must be zero to keep the format canonical
+	if err != nil || sortableEncoding.EncodeToString(b) != str {
+		return Nil, errInvalid("invalid sortable uuid: " + str)
+	}
+
+	return fromRaw(b)
+}
+
+const (
+	proquintConsonants = "bdfghjklmnprstvz"
+	proquintVowels     = "aiou"
+)
+
+// Proquint returns the uuid as eight hyphen separated proquints, eg: puzoh-bipig-mutog-hitok-milud-fihap-hanuz-dukuj
+// Every 5 letters long proquint encodes 2 bytes, see: https://arxiv.org/abs/0901.4016
+// Returns an empty string for Nil and malformed uuids.
+func (u UUID) Proquint() string {
+	if u == Nil {
+		return ""
+	}
+
+	b, err := u.decode()
+	if err != nil {
+		return ""
+	}
+
+	res := make([]byte, 0, 8*6-1)
+	for i := 0; i < size; i += 2 {
+		if i > 0 {
+			res = append(res, '-')
+		}
+
+		w := uint16(b[i])<<8 | uint16(b[i+1])
+		res = append(res,
+			proquintConsonants[w>>12&0x0f],
+			proquintVowels[w>>10&0x03],
+			proquintConsonants[w>>6&0x0f],
+			proquintVowels[w>>4&0x03],
+			proquintConsonants[w&0x0f],
+		)
+	}
+
+	return string(res)
+}
+
+// FromProquint parses uuid in the format returned by Proquint, eg: puzoh-bipig-mutog-hitok-milud-fihap-hanuz-dukuj
+// It is case-insensitive and the hyphens are optional. The decoded uuid is validated as in FromString.
+func FromProquint(str string) (UUID, error) {
+	if str == "" {
+		return Nil, nil
+	}
+
+	letters := make([]byte, 0, 40)
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		if c == '-' {
+			continue
+		}
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		letters = append(letters, c)
+	}
+
+	if len(letters) != 40 {
+		return Nil, errInvalid("invalid proquint uuid, must be 8 proquints: " + str)
+	}
+
+	b := [size]byte{}
+	for i := 0; i < 8; i++ {
+		var w uint16
+		for j, c := range letters[i*5 : i*5+5] {
+			alphabet, bits := proquintConsonants, 4
+			if j%2 == 1 {
+				alphabet, bits = proquintVowels, 2
+			}
+
+			d := strings.IndexByte(alphabet, c)
+			if d < 0 {
+				return Nil, errInvalid("invalid proquint uuid, invalid proquint " + string(letters[i*5:i*5+5]) + ": " + str)
+			}
+			w = w<<bits | uint16(d)
+		}
+
+		b[2*i] = byte(w >> 8)
+		b[2*i+1] = byte(w)
+	}
+
+	return fromRaw(b[:])
+}
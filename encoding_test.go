@@ -0,0 +1,412 @@
+package uuid
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+	"unicode"
+)
+
+func TestShort(t *testing.T) {
+	for _, data := range []struct {
+		original UUID
+		want     string
+	}{
+		{original: "", want: ""},
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", want: "r-QGk49jR2aF8SUKQn8dtQ"},
+		{original: "43ae2f25-802d-4aae-be57-b7acefe336ac", want: "Q64vJYAtSq6-V7es7-M2rA"},
+		{original: Max, want: "_____________________w"},
+	} {
+		got := data.original.Short()
+		if data.want != got {
+			t.Errorf("want: %s, got: %s", data.want, got)
+		}
+
+		back, err := FromShort(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back != data.original {
+			t.Errorf("want: %s, got: %s", data.original, back)
+		}
+	}
+}
+
+func TestShortRandom(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		u := NewV4()
+
+		short := u.Short()
+		if len(short) != 22 {
+			t.Fatalf("invalid short uuid length: %v", short)
+		}
+
+		back, err := FromShort(short)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back != u {
+			t.Fatalf("want: %s, got: %s", u, back)
+		}
+	}
+}
+
+func TestFromShortError(t *testing.T) {
+	for _, data := range []struct {
+		name     string
+		original string
+	}{
+		{name: "too short", original: "r-QGk49jR2aF8SUKQn8dt"},
+		{name: "padded", original: "r-QGk49jR2aF8SUKQn8dtQ=="},
+		{name: "24 characters", original: "r-QGk49jR2aF8SUKQn8dtQAA"},
+		{name: "standard alphabet", original: "r+QGk49jR2aF8SUKQn8dtQ"},
+		{name: "invalid character", original: "r-QGk49jR2aF8SUKQn8dt!"},
+		{name: "non-zero trailing bits", original: "r-QGk49jR2aF8SUKQn8dtR"},
+		{name: "invalid version bit", original: "mZmZmZmZaZmZmSUKQn8dtQ"},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			_, err := FromShort(data.original)
+			if err == nil {
+				t.Errorf("expected error, but got nothing for %v", data.original)
+			}
+		})
+	}
+}
+
+func TestBase58(t *testing.T) {
+	for _, data := range []struct {
+		original UUID
+		want     string
+	}{
+		{original: "", want: ""},
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", want: "NikCFgDe7RmG2a8p8nnnua"},
+		{original: "43ae2f25-802d-4aae-be57-b7acefe336ac", want: "9MjWkHnYB6dG4kyRjpo6ew"},
+		{original: "00000000-0000-4000-8000-000000000001", want: "1111114bZ6BZRUqUqZep"},
+		{original: Max, want: "YcVfxkQb6JRzqk5kF2tNLv"},
+	} {
+		got := data.original.Base58()
+		if data.want != got {
+			t.Errorf("want: %s, got: %s", data.want, got)
+		}
+
+		back, err := FromBase58(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back != data.original {
+			t.Errorf("want: %s, got: %s", data.original, back)
+		}
+	}
+}
+
+func TestBase58Random(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		u := NewV4()
+
+		back, err := FromBase58(u.Base58())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back != u {
+			t.Fatalf("want: %s, got: %s", u, back)
+		}
+	}
+}
+
+func TestFromBase58Error(t *testing.T) {
+	for _, data := range []struct {
+		name     string
+		original string
+	}{
+		{name: "invalid character 0", original: "0ikCFgDe7RmG2a8p8nnnua"},
+		{name: "invalid character O", original: "OikCFgDe7RmG2a8p8nnnua"},
+		{name: "invalid character l", original: "likCFgDe7RmG2a8p8nnnua"},
+		{name: "longer than 128 bits", original: "YcVfxkQb6JRzqk5kF2tNLw"},
+		{name: "too long", original: "NikCFgDe7RmG2a8p8nnnuaa"},
+		{name: "shorter than 128 bits", original: "NikCFgDe7RmG2a8p8nnnu"},
+		{name: "extra leading zero", original: "1NikCFgDe7RmG2a8p8nnnua"},
+		{name: "invalid version bit", original: "Ky6byeeMQgecGvuaeFGuHn"},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			_, err := FromBase58(data.original)
+			if err == nil {
+				t.Errorf("expected error, but got nothing for %v", data.original)
+			}
+		})
+	}
+}
+
+func TestBase32Crockford(t *testing.T) {
+	for _, data := range []struct {
+		original UUID
+		want     string
+	}{
+		{original: "", want: ""},
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", want: "5FWG3973V38XK8BW951917Y7DN"},
+		{original: "43ae2f25-802d-4aae-be57-b7acefe336ac", want: "23NRQJB01D9AQBWNXQNKQY6DNC"},
+		{original: Max, want: "7ZZZZZZZZZZZZZZZZZZZZZZZZZ"},
+	} {
+		got := data.original.Base32Crockford()
+		if data.want != got {
+			t.Errorf("want: %s, got: %s", data.want, got)
+		}
+
+		back, err := FromBase32Crockford(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back != data.original {
+			t.Errorf("want: %s, got: %s", data.original, back)
+		}
+	}
+}
+
+func TestFromBase32Crockford(t *testing.T) {
+	want := UUID("afe40693-8f63-4766-85f1-250a427f1db5")
+
+	for _, original := range []string{
+		"5FWG3973V38XK8BW951917Y7DN",
+		"5fwg3973v38xk8bw951917y7dn",
+		"5FWG-3973-V38X-K8BW-9519-17Y7-DN",
+		"5FWG3973V38XK8BW95I9L7Y7DN",
+		"5fwg-3973-v38x-k8bw-95i9-l7y7-dn",
+		"-5FWG3973V38XK8BW951917Y7DN-",
+	} {
+		got, err := FromBase32Crockford(original)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want != got {
+			t.Errorf("FromBase32Crockford(%v) want: %s, got: %s", original, want, got)
+		}
+	}
+
+	got, err := FromBase32Crockford("OOOOOOOOOOOOOOOOOOOOOOOOOO")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != Nil {
+		t.Errorf("want: Nil, got: %s", got)
+	}
+}
+
+func TestFromBase32CrockfordError(t *testing.T) {
+	for _, data := range []struct {
+		name     string
+		original string
+	}{
+		{name: "too short", original: "5FWG3973V38XK8BW951917Y7D"},
+		{name: "too long", original: "5FWG3973V38XK8BW951917Y7DNA"},
+		{name: "longer than 128 bits", original: "8ZZZZZZZZZZZZZZZZZZZZZZZZZ"},
+		{name: "invalid character U", original: "5FWG3973V38XK8BW951917Y7DU"},
+		{name: "invalid character", original: "5FWG3973V38XK8BW951917Y7D!"},
+		{name: "hyphens only", original: "---"},
+		{name: "invalid version bit", original: "4SK6CSK6CSD6CSK6951917Y7DN"},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			_, err := FromBase32Crockford(data.original)
+			if err == nil {
+				t.Errorf("expected error, but got nothing for %v", data.original)
+			}
+		})
+	}
+}
+
+func TestBase32CrockfordRandom(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+
+	for i := 0; i < 1000; i++ {
+		u := NewV4()
+
+		// mix the case and group randomly, the decoder must not care
+		encoded := []byte(u.Base32Crockford())
+		var mixed []byte
+		for _, c := range encoded {
+			if r.IntN(2) == 0 {
+				c = byte(unicode.ToLower(rune(c)))
+			}
+			mixed = append(mixed, c)
+			if r.IntN(5) == 0 {
+				mixed = append(mixed, '-')
+			}
+		}
+
+		back, err := FromBase32Crockford(string(mixed))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back != u {
+			t.Fatalf("want: %s, got: %s", u, back)
+		}
+	}
+
+	// random input must never panic, and anything accepted must be a valid uuid
+	const alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz-!"
+	for i := 0; i < 10000; i++ {
+		b := make([]byte, r.IntN(30))
+		for j := range b {
+			b[j] = alphabet[r.IntN(len(alphabet))]
+		}
+
+		u, err := FromBase32Crockford(string(b))
+		if err != nil {
+			continue
+		}
+		if _, err := FromString(u.String()); err != nil {
+			t.Fatalf("FromBase32Crockford(%s) returned invalid uuid: %v", b, u)
+		}
+	}
+}
+
+func TestSortableString(t *testing.T) {
+	for _, data := range []struct {
+		original UUID
+		want     string
+	}{
+		{original: "", want: ""},
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", want: "LVI0D4SFCD3MD1FH4K544VOTMK"},
+		{original: Max, want: "VVVVVVVVVVVVVVVVVVVVVVVVVS"},
+	} {
+		got := data.original.SortableString()
+		if data.want != got {
+			t.Errorf("want: %s, got: %s", data.want, got)
+		}
+
+		back, err := FromSortableString(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back != data.original {
+			t.Errorf("want: %s, got: %s", data.original, back)
+		}
+	}
+}
+
+func TestSortableStringOrder(t *testing.T) {
+	base := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	uuids := make([]UUID, 0, 2000)
+	for i := 0; i < 1000; i++ {
+		uuids = append(uuids, NewTime(base.Add(time.Duration(i%50)*time.Millisecond)))
+		uuids = append(uuids, NewTime(base.Add(time.Duration(i)*time.Hour)))
+	}
+
+	for i := 1; i < len(uuids); i++ {
+		a, b := uuids[i-1], uuids[i]
+
+		if (a.String() < b.String()) != (a.SortableString() < b.SortableString()) {
+			t.Fatalf("order is not preserved for %v and %v", a, b)
+		}
+	}
+
+	byString := append([]UUID(nil), uuids...)
+	sort.Slice(byString, func(i, j int) bool { return byString[i].String() < byString[j].String() })
+	bySortable := append([]UUID(nil), uuids...)
+	sort.Slice(bySortable, func(i, j int) bool { return bySortable[i].SortableString() < bySortable[j].SortableString() })
+
+	if !reflect.DeepEqual(byString, bySortable) {
+		t.Error("sorting by SortableString differs from sorting by String")
+	}
+}
+
+func TestFromSortableStringError(t *testing.T) {
+	for _, data := range []struct {
+		name     string
+		original string
+	}{
+		{name: "too short", original: "LVI0D4SFCD3MD1FH4K544VOTM"},
+		{name: "padded", original: "LVI0D4SFCD3MD1FH4K544VOTMK======"},
+		{name: "lowercase", original: "lvi0d4sfcd3md1fh4k544votmk"},
+		{name: "invalid character", original: "LVI0D4SFCD3MD1FH4K544VOTMZ"},
+		{name: "non-zero trailing bits", original: "LVI0D4SFCD3MD1FH4K544VOTML"},
+		{name: "invalid version bit", original: "J6CPJ6CPJ5KPJ6CP4K544VOTMK"},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			_, err := FromSortableString(data.original)
+			if err == nil {
+				t.Errorf("expected error, but got nothing for %v", data.original)
+			}
+		})
+	}
+}
+
+func TestProquint(t *testing.T) {
+	for _, data := range []struct {
+		original UUID
+		want     string
+	}{
+		{original: "", want: ""},
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", want: "puzoh-bipig-mutog-hitok-milud-fihap-hanuz-dukuj"},
+		// the first 4 bytes are 127.0.0.1, the example of the proquint spec
+		{original: "7f000001-0000-4000-8000-000000000000", want: "lusab-babad-babab-habab-mabab-babab-babab-babab"},
+	} {
+		got := data.original.Proquint()
+		if data.want != got {
+			t.Errorf("want: %s, got: %s", data.want, got)
+		}
+
+		back, err := FromProquint(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back != data.original {
+			t.Errorf("want: %s, got: %s", data.original, back)
+		}
+	}
+}
+
+func TestFromProquint(t *testing.T) {
+	want := UUID("afe40693-8f63-4766-85f1-250a427f1db5")
+
+	for _, original := range []string{
+		"puzoh-bipig-mutog-hitok-milud-fihap-hanuz-dukuj",
+		"PUZOH-BIPIG-MUTOG-HITOK-MILUD-FIHAP-HANUZ-DUKUJ",
+		"puzohbipigmutoghitokmiludfihaphanuzdukuj",
+		"puzoh-bipigmutog-hitok-MILUD-fihap-hanuzdukuj",
+	} {
+		got, err := FromProquint(original)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want != got {
+			t.Errorf("FromProquint(%v) want: %s, got: %s", original, want, got)
+		}
+	}
+}
+
+func TestProquintRandom(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		u := NewV4()
+
+		back, err := FromProquint(u.Proquint())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back != u {
+			t.Fatalf("want: %s, got: %s", u, back)
+		}
+	}
+}
+
+func TestFromProquintError(t *testing.T) {
+	for _, data := range []struct {
+		name     string
+		original string
+	}{
+		{name: "too short", original: "puzoh-bipig-mutog-hitok-milud-fihap-hanuz"},
+		{name: "too long", original: "puzoh-bipig-mutog-hitok-milud-fihap-hanuz-dukuj-babab"},
+		{name: "vowel instead of consonant", original: "auzoh-bipig-mutog-hitok-milud-fihap-hanuz-dukuj"},
+		{name: "consonant instead of vowel", original: "pbzoh-bipig-mutog-hitok-milud-fihap-hanuz-dukuj"},
+		{name: "invalid letter", original: "puzoh-bipig-mutog-hitok-milud-fihap-hanuz-dukuc"},
+		{name: "invalid version bit", original: "lusab-babad-babab-babab-labab-babab-babab-babab"},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			_, err := FromProquint(data.original)
+			if err == nil {
+				t.Errorf("expected error, but got nothing for %v", data.original)
+			}
+		})
+	}
+}
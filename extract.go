@@ -0,0 +1,122 @@
+package uuid
+
+import (
+	"io"
+)
+
+// Extract returns every uuid in canonical format found in text, eg: in log lines, in order of first appearance
+// without duplicates. Uuids must be separated from the surrounding text by non-alphanumeric characters and
+// are validated as in FromString, Nil is never returned.
+func Extract(text string) []UUID {
+	e := newExtractor(false)
+	extractFrom(e, text, 0, len(text))
+
+	return e.uuids
+}
+
+// ExtractWithHashLike is like Extract, but also returns uuids found in hash format.
+func ExtractWithHashLike(text string) []UUID {
+	e := newExtractor(true)
+	extractFrom(e, text, 0, len(text))
+
+	return e.uuids
+}
+
+// ExtractReader is like Extract (or ExtractWithHashLike if hashLike is true), but reads the text from r
+// without keeping all of it in memory. Read errors are returned together with the uuids found before the error.
+func ExtractReader(r io.Reader, hashLike bool) ([]UUID, error) {
+	const chunkSize = 32 * 1024
+
+	e := newExtractor(hashLike)
+	buf := make([]byte, 0, 2*chunkSize)
+	pos := 0
+	for {
+		if cap(buf)-len(buf) < chunkSize {
+			buf = append(buf, make([]byte, chunkSize)...)[:len(buf)]
+		}
+
+		n, err := r.Read(buf[len(buf) : len(buf)+chunkSize])
+		buf = buf[:len(buf)+n]
+
+		if err != nil && err != io.EOF {
+			return e.uuids, err
+		}
+
+		if err == io.EOF {
+			extractFrom(e, buf, pos, len(buf))
+			return e.uuids, nil
+		}
+
+		// only check positions where the character after the longest possible match is already read
+		pos = extractFrom(e, buf, pos, len(buf)-37)
+
+		// keep one character before pos for the boundary check
+		if pos > 1 {
+			drop := pos - 1
+			buf = buf[:copy(buf, buf[drop:])]
+			pos -= drop
+		}
+	}
+}
+
+type extractor struct {
+	hashLike bool
+	seen     map[UUID]struct{}
+	uuids    []UUID
+}
+
+func newExtractor(hashLike bool) *extractor {
+	return &extractor{
+		hashLike: hashLike,
+		seen:     make(map[UUID]struct{}),
+		uuids:    []UUID{},
+	}
+}
+
+func (e *extractor) add(u UUID) {
+	if u == Nil {
+		return
+	}
+
+	if _, ok := e.seen[u]; ok {
+		return
+	}
+
+	e.seen[u] = struct{}{}
+	e.uuids = append(e.uuids, u)
+}
+
+// extractFrom checks the positions of text in [from, to) for uuids and returns the position where the next check should start.
+func extractFrom[T string | []byte](e *extractor, text T, from int, to int) int {
+	i := from
+	for i < to {
+		if i > 0 && isAlphanumeric(text[i-1]) || !isHex(text[i]) {
+			i++
+			continue
+		}
+
+		if end := i + 36; end <= len(text) && (end == len(text) || !isAlphanumeric(text[end])) && isHexLayout(text[i:end]) {
+			if u, err := FromString(string(text[i:end])); err == nil {
+				e.add(u)
+				i = end
+				continue
+			}
+		}
+
+		if end := i + 32; e.hashLike && end <= len(text) && (end == len(text) || !isAlphanumeric(text[end])) {
+			if u, err := FromHashLike(string(text[i:end])); err == nil {
+				e.add(u)
+				i = end
+				continue
+			}
+		}
+
+		i++
+	}
+
+	return i
+}
+
+func isAlphanumeric(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
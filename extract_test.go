@@ -0,0 +1,93 @@
+package uuid
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+const extractText = `2024-01-02T03:04:05Z ERROR request afe40693-8f63-4766-85f1-250a427f1db5 failed: user=(43AE2F25-802D-4AAE-BE57-B7ACEFE336AC),
+	retry of "afe40693-8f63-4766-85f1-250a427f1db5"; parent={b94d27b9-934d-4e08-a52e-52d7da7dabfa}
+	invalid: 99999999-9999-6999-9999-250a427f1db5 zero: 00000000-0000-0000-0000-000000000000
+	glued: xafe40693-8f63-4766-85f1-250a427f1db5 afe40693-8f63-4766-85f1-250a427f1db5x
+	hash: d7a8fbb307d74094a9ca9abcb0082e4f sha1: 2fd4e1c67a2d28fced849ee1bb76e7391b93eb12
+	urn:uuid:e3b0c442-98fc-4c14-9afb-f4c8996fb924.`
+
+func TestExtract(t *testing.T) {
+	want := []UUID{
+		"afe40693-8f63-4766-85f1-250a427f1db5",
+		"43ae2f25-802d-4aae-be57-b7acefe336ac",
+		"b94d27b9-934d-4e08-a52e-52d7da7dabfa",
+		"e3b0c442-98fc-4c14-9afb-f4c8996fb924",
+	}
+
+	if got := Extract(extractText); !reflect.DeepEqual(want, got) {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+
+	got, err := ExtractReader(iotest.OneByteReader(strings.NewReader(extractText)), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+}
+
+func TestExtractWithHashLike(t *testing.T) {
+	want := []UUID{
+		"afe40693-8f63-4766-85f1-250a427f1db5",
+		"43ae2f25-802d-4aae-be57-b7acefe336ac",
+		"b94d27b9-934d-4e08-a52e-52d7da7dabfa",
+		"d7a8fbb3-07d7-4094-a9ca-9abcb0082e4f",
+		"e3b0c442-98fc-4c14-9afb-f4c8996fb924",
+	}
+
+	if got := ExtractWithHashLike(extractText); !reflect.DeepEqual(want, got) {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+
+	got, err := ExtractReader(strings.NewReader(extractText), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+}
+
+func TestExtractEmpty(t *testing.T) {
+	for _, text := range []string{"", "no uuids here", "afe40693-8f63-4766-85f1-250a427f1db"} {
+		if got := Extract(text); len(got) != 0 {
+			t.Errorf("want no uuids, got: %v", got)
+		}
+	}
+}
+
+func TestExtractLarge(t *testing.T) {
+	var sb strings.Builder
+	want := make([]UUID, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		u := NewV4()
+		want = append(want, u)
+
+		// put the uuids at varying offsets, so some of them span the chunks of ExtractReader
+		sb.WriteString(strings.Repeat("lorem ipsum, ", i%97))
+		sb.WriteString(u.String())
+		sb.WriteString(strings.Repeat("-", i%13) + " ")
+	}
+	text := sb.String()
+
+	if got := Extract(text); !reflect.DeepEqual(want, got) {
+		t.Errorf("Extract found %v uuids, want: %v", len(got), len(want))
+	}
+
+	got, err := ExtractReader(iotest.HalfReader(strings.NewReader(text)), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("ExtractReader found %v uuids, want: %v", len(got), len(want))
+	}
+}
@@ -0,0 +1,81 @@
+package uuid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"hash"
+)
+
+const (
+	feistelRounds = 8
+	feistelMask   = 1<<61 - 1
+)
+
+// feistel is a keyed permutation over the 122 bits of a uuid which are not version or variant bits.
+// The free bits, in order, are split into two 61 bits halves l and r. Each round i (0-7) computes
+// l, r = r, l ^ F(i, r), where F is the first 8 bytes of HMAC-SHA256(key, tweak || i || r as 8 bytes big-endian)
+// as a big-endian integer, truncated to 61 bits.
+type feistel struct {
+	mac   hash.Hash
+	tweak string
+	buf   []byte
+}
+
+func newFeistel(key []byte, tweak string) *feistel {
+	return &feistel{
+		mac:   hmac.New(sha256.New, key),
+		tweak: tweak,
+		buf:   make([]byte, 0, len(tweak)+9),
+	}
+}
+
+func (f *feistel) round(i byte, half uint64) uint64 {
+	f.buf = append(f.buf[:0], f.tweak...)
+	f.buf = append(f.buf, i)
+	f.buf = binary.BigEndian.AppendUint64(f.buf, half)
+
+	f.mac.Reset()
+	f.mac.Write(f.buf)
+
+	return binary.BigEndian.Uint64(f.mac.Sum(nil)) & feistelMask
+}
+
+func (f *feistel) encrypt(l, r uint64) (uint64, uint64) {
+	for i := byte(0); i < feistelRounds; i++ {
+		l, r = r, l^f.round(i, r)
+	}
+
+	return l, r
+}
+
+func (f *feistel) decrypt(l, r uint64) (uint64, uint64) {
+	for i := byte(feistelRounds); i > 0; i-- {
+		l, r = r^f.round(i-1, l), l
+	}
+
+	return l, r
+}
+
+// splitFree returns the 122 bits of the uuid which are not version or variant bits as two 61 bits halves.
+func splitFree(b [size]byte) (uint64, uint64) {
+	hi := binary.BigEndian.Uint64(b[0:8])
+	lo := binary.BigEndian.Uint64(b[8:16])
+
+	hi = hi>>16<<12 | hi&0xfff
+	lo &= 1<<62 - 1
+
+	return hi<<1 | lo>>61, lo & feistelMask
+}
+
+// joinFree is the reverse of splitFree, the version and variant bits are taken from orig.
+func joinFree(l, r uint64, orig [size]byte) [size]byte {
+	hi := binary.BigEndian.Uint64(orig[0:8])&0xf000 | l>>13<<16 | l>>1&0xfff
+	lo := binary.BigEndian.Uint64(orig[8:16])&^(1<<62-1) | (l&1)<<61 | r
+
+	b := [size]byte{}
+	binary.BigEndian.PutUint64(b[0:8], hi)
+	binary.BigEndian.PutUint64(b[8:16], lo)
+
+	return b
+}
@@ -0,0 +1,42 @@
+package uuid
+
+import (
+	"testing"
+)
+
+func TestSplitJoinFree(t *testing.T) {
+	for _, original := range []UUID{
+		"afe40693-8f63-4766-85f1-250a427f1db5",
+		"ffffffff-ffff-4fff-bfff-ffffffffffff",
+		"00000000-0000-1000-8000-000000000000",
+	} {
+		b, err := original.decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		l, r := splitFree(b)
+		if l > feistelMask || r > feistelMask {
+			t.Errorf("halves longer than 61 bits for %v: %x, %x", original, l, r)
+		}
+
+		if got := joinFree(l, r, b); got != b {
+			t.Errorf("want: %x, got: %x", b, got)
+		}
+	}
+}
+
+func TestFeistel(t *testing.T) {
+	f := newFeistel([]byte("key"), "test")
+
+	for _, data := range [][2]uint64{{0, 0}, {1, 2}, {feistelMask, feistelMask}} {
+		l, r := f.encrypt(data[0], data[1])
+		if l > feistelMask || r > feistelMask {
+			t.Errorf("encrypted halves longer than 61 bits: %x, %x", l, r)
+		}
+
+		if l, r = f.decrypt(l, r); l != data[0] || r != data[1] {
+			t.Errorf("want: %x, got: %x", data, [2]uint64{l, r})
+		}
+	}
+}
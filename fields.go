@@ -0,0 +1,148 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fields holds the fields of a uuid as defined in RFC 4122 section 4.1.2.
+type Fields struct {
+	TimeLow               uint32
+	TimeMid               uint16
+	TimeHiAndVersion      uint16
+	ClockSeqHiAndReserved uint8
+	ClockSeqLow           uint8
+	Node                  [6]byte
+}
+
+// Fields returns the RFC 4122 fields of the uuid.
+// Returns ErrNil for Nil and an error for malformed uuids.
+func (u UUID) Fields() (Fields, error) {
+	b, err := u.Bytes()
+	if err != nil {
+		return Fields{}, err
+	}
+
+	f := Fields{
+		TimeLow:               binary.BigEndian.Uint32(b[0:4]),
+		TimeMid:               binary.BigEndian.Uint16(b[4:6]),
+		TimeHiAndVersion:      binary.BigEndian.Uint16(b[6:8]),
+		ClockSeqHiAndReserved: b[8],
+		ClockSeqLow:           b[9],
+	}
+	copy(f.Node[:], b[10:])
+
+	return f, nil
+}
+
+// plausibleTimeMin and plausibleTimeMax is the range of timestamps considered plausible for time uuids,
+// the first 48 bits of random uuids fall into it with a chance of about 1%.
+var (
+	plausibleTimeMin = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	plausibleTimeMax = time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// Description is a human-readable summary of a uuid, see Describe.
+type Description struct {
+	Canonical string
+	HashLike  string
+	Version   byte
+	Variant   Variant
+	// IsTime reports whether the uuid looks like a time uuid: IsTimeUUID is true and the timestamp is between
+	// 2000 and 2100.
+	IsTime bool
+	// Time is the timestamp of the uuid as in TimeUUIDToTime, the zero time if IsTime is false.
+	Time time.Time
+}
+
+// Describe returns a summary of the uuid, eg: for support tooling.
+// Returns ErrNil for Nil and an error for malformed uuids.
+func Describe(u UUID) (Description, error) {
+	f, err := u.Fields()
+	if err != nil {
+		return Description{}, err
+	}
+
+	canonical := strings.ToLower(u.String())
+	d := Description{
+		Canonical: canonical,
+		HashLike:  UUID(canonical).HashLike(),
+		Version:   byte(f.TimeHiAndVersion >> 12),
+		Variant:   u.Variant(),
+	}
+
+	if u.IsTimeUUID() {
+		t := Time(uint64(f.TimeLow)<<16 | uint64(f.TimeMid))
+		if inTimeRange(t, plausibleTimeMin, plausibleTimeMax) {
+			d.IsTime = true
+			d.Time = t
+		}
+	}
+
+	return d, nil
+}
+
+// String returns the description in multiple lines, the time is omitted if the uuid does not look like
+// a time uuid.
+func (d Description) String() string {
+	var sb strings.Builder
+
+	sb.WriteString("uuid:      " + d.Canonical + "\n")
+	sb.WriteString("hash-like: " + d.HashLike + "\n")
+	sb.WriteString("version:   " + strconv.Itoa(int(d.Version)) + "\n")
+	sb.WriteString("variant:   " + d.Variant.String() + "\n")
+	if d.IsTime {
+		sb.WriteString("time:      " + d.Time.Format(time.RFC3339Nano) + "\n")
+	}
+
+	return sb.String()
+}
+
+// ErrWrongVersion is returned for uuids of an unexpected version, eg: by NodeID and ClockSequence for uuids
+// which are not version 1 or 6.
+var ErrWrongVersion = errors.New("wrong uuid version")
+
+// NodeID returns the node of a version 1 or 6 uuid, usually the MAC address of the generating host.
+// Returns ErrNil for Nil, ErrWrongVersion for other versions and an error for malformed uuids.
+func (u UUID) NodeID() ([6]byte, error) {
+	f, err := u.timeFields()
+	if err != nil {
+		return [6]byte{}, err
+	}
+
+	return f.Node, nil
+}
+
+// ClockSequence returns the 14 bits clock sequence of a version 1 or 6 uuid.
+// Returns ErrNil for Nil, ErrWrongVersion for other versions and an error for malformed uuids.
+func (u UUID) ClockSequence() (uint16, error) {
+	f, err := u.timeFields()
+	if err != nil {
+		return 0, err
+	}
+
+	return uint16(f.ClockSeqHiAndReserved&0x3f)<<8 | uint16(f.ClockSeqLow), nil
+}
+
+// IsGlobalNode reports whether the multicast bit of the node is unset, meaning it is a real MAC address
+// and not a random node id, see RFC 4122 section 4.5.
+func IsGlobalNode(node [6]byte) bool {
+	return node[0]&0x01 == 0
+}
+
+// timeFields returns the fields of version 1 and 6 uuids.
+func (u UUID) timeFields() (Fields, error) {
+	f, err := u.Fields()
+	if err != nil {
+		return Fields{}, err
+	}
+
+	if v := f.TimeHiAndVersion >> 12; v != 1 && v != 6 {
+		return Fields{}, ErrWrongVersion
+	}
+
+	return f, nil
+}
@@ -0,0 +1,206 @@
+package uuid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFields(t *testing.T) {
+	for _, data := range []struct {
+		original UUID
+		want     Fields
+	}{
+		{
+			original: "afe40693-8f63-4766-85f1-250a427f1db5",
+			want: Fields{
+				TimeLow:               0xafe40693,
+				TimeMid:               0x8f63,
+				TimeHiAndVersion:      0x4766,
+				ClockSeqHiAndReserved: 0x85,
+				ClockSeqLow:           0xf1,
+				Node:                  [6]byte{0x25, 0x0a, 0x42, 0x7f, 0x1d, 0xb5},
+			},
+		},
+		{
+			original: "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+			want: Fields{
+				TimeLow:               0x6ba7b810,
+				TimeMid:               0x9dad,
+				TimeHiAndVersion:      0x11d1,
+				ClockSeqHiAndReserved: 0x80,
+				ClockSeqLow:           0xb4,
+				Node:                  [6]byte{0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8},
+			},
+		},
+	} {
+		got, err := data.original.Fields()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != data.want {
+			t.Errorf("want: %+v, got: %+v", data.want, got)
+		}
+	}
+}
+
+func TestFieldsError(t *testing.T) {
+	if _, err := Nil.Fields(); err != ErrNil {
+		t.Errorf("want: %v, got: %v", ErrNil, err)
+	}
+
+	for _, orig := range []UUID{"asda", "afe40693-8f63-4766-85f1-250a427f1dbx"} {
+		t.Run(orig.String(), func(t *testing.T) {
+			if _, err := orig.Fields(); err == nil {
+				t.Errorf("expected error, but got nothing for %v", orig)
+			}
+		})
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	for _, data := range []struct {
+		original UUID
+		want     Description
+		str      string
+	}{
+		{
+			original: "016D6C41-26BB-4766-A5F1-250A427F1DB5",
+			want: Description{
+				Canonical: "016d6c41-26bb-4766-a5f1-250a427f1db5",
+				HashLike:  "016d6c4126bb4766a5f1250a427f1db5",
+				Version:   4,
+				Variant:   VariantRFC4122,
+				IsTime:    true,
+				Time:      time.Date(2019, 9, 26, 6, 27, 52, 123000000, time.UTC),
+			},
+			str: "uuid:      016d6c41-26bb-4766-a5f1-250a427f1db5\n" +
+				"hash-like: 016d6c4126bb4766a5f1250a427f1db5\n" +
+				"version:   4\n" +
+				"variant:   RFC4122\n" +
+				"time:      2019-09-26T06:27:52.123Z\n",
+		},
+		{
+			// plausible timestamp, but no time marker
+			original: "016d6c41-26bb-4766-85f1-250a427f1db5",
+			want: Description{
+				Canonical: "016d6c41-26bb-4766-85f1-250a427f1db5",
+				HashLike:  "016d6c4126bb476685f1250a427f1db5",
+				Version:   4,
+				Variant:   VariantRFC4122,
+			},
+			str: "uuid:      016d6c41-26bb-4766-85f1-250a427f1db5\n" +
+				"hash-like: 016d6c4126bb476685f1250a427f1db5\n" +
+				"version:   4\n" +
+				"variant:   RFC4122\n",
+		},
+		{
+			original: "afe40693-8f63-4766-85f1-250a427f1db5",
+			want: Description{
+				Canonical: "afe40693-8f63-4766-85f1-250a427f1db5",
+				HashLike:  "afe406938f63476685f1250a427f1db5",
+				Version:   4,
+				Variant:   VariantRFC4122,
+			},
+			str: "uuid:      afe40693-8f63-4766-85f1-250a427f1db5\n" +
+				"hash-like: afe406938f63476685f1250a427f1db5\n" +
+				"version:   4\n" +
+				"variant:   RFC4122\n",
+		},
+		{
+			original: "016d6c41-26bb-11d1-80b4-00c04fd430c8",
+			want: Description{
+				Canonical: "016d6c41-26bb-11d1-80b4-00c04fd430c8",
+				HashLike:  "016d6c4126bb11d180b400c04fd430c8",
+				Version:   1,
+				Variant:   VariantRFC4122,
+			},
+			str: "uuid:      016d6c41-26bb-11d1-80b4-00c04fd430c8\n" +
+				"hash-like: 016d6c4126bb11d180b400c04fd430c8\n" +
+				"version:   1\n" +
+				"variant:   RFC4122\n",
+		},
+	} {
+		got, err := Describe(data.original)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != data.want {
+			t.Errorf("want: %+v, got: %+v", data.want, got)
+		}
+		if got.String() != data.str {
+			t.Errorf("want: %q, got: %q", data.str, got.String())
+		}
+	}
+
+	d, err := Describe(NewTime(time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !d.IsTime {
+		t.Errorf("expected time uuid, got: %+v", d)
+	}
+
+	for _, orig := range []UUID{Nil, "asda"} {
+		if _, err := Describe(orig); err == nil {
+			t.Errorf("expected error, but got nothing for %v", orig)
+		}
+	}
+}
+
+func TestNodeIDClockSequence(t *testing.T) {
+	for _, data := range []struct {
+		original UUID
+		node     [6]byte
+		clockSeq uint16
+		global   bool
+	}{
+		{original: "6ba7b810-9dad-11d1-80b4-00c04fd430c8", node: [6]byte{0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}, clockSeq: 0x00b4, global: true},
+		{original: "1ec9414c-232a-6b00-b3c8-9f6bdeced846", node: [6]byte{0x9f, 0x6b, 0xde, 0xce, 0xd8, 0x46}, clockSeq: 0x33c8, global: false},
+		{original: "C232AB00-9414-11EC-BFFF-0242AC120002", node: [6]byte{0x02, 0x42, 0xac, 0x12, 0x00, 0x02}, clockSeq: 0x3fff, global: true},
+	} {
+		node, err := data.original.NodeID()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if node != data.node {
+			t.Errorf("want: %x, got: %x", data.node, node)
+		}
+
+		clockSeq, err := data.original.ClockSequence()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if clockSeq != data.clockSeq {
+			t.Errorf("want: %x, got: %x", data.clockSeq, clockSeq)
+		}
+
+		if got := IsGlobalNode(node); got != data.global {
+			t.Errorf("IsGlobalNode(%x) want: %v, got: %v", node, data.global, got)
+		}
+	}
+}
+
+func TestNodeIDClockSequenceError(t *testing.T) {
+	for _, data := range []struct {
+		name     string
+		original UUID
+		want     error
+	}{
+		{name: "nil", original: Nil, want: ErrNil},
+		{name: "v4", original: "afe40693-8f63-4766-85f1-250a427f1db5", want: ErrWrongVersion},
+		{name: "max", original: Max, want: ErrWrongVersion},
+		{name: "malformed", original: "asda"},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			_, err := data.original.NodeID()
+			if err == nil || data.want != nil && err != data.want {
+				t.Errorf("want: %v, got: %v", data.want, err)
+			}
+
+			_, err = data.original.ClockSequence()
+			if err == nil || data.want != nil && err != data.want {
+				t.Errorf("want: %v, got: %v", data.want, err)
+			}
+		})
+	}
+}
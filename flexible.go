@@ -0,0 +1,51 @@
+package uuid
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// FlexibleUUID is a UUID which accepts every format of ParseAny when unmarshalled: canonical, hash, braced and urn,
+// eg: for clients sending ids without dashes. It is marshalled in canonical format like UUID.
+type FlexibleUUID UUID
+
+func (u FlexibleUUID) UUID() UUID {
+	return UUID(u)
+}
+
+func (u FlexibleUUID) String() string {
+	return string(u)
+}
+
+func (u FlexibleUUID) MarshalText() ([]byte, error) {
+	return UUID(u).MarshalText()
+}
+
+func (u *FlexibleUUID) UnmarshalText(text []byte) error {
+	uid, err := ParseAny(string(text))
+	if err != nil {
+		return err
+	}
+
+	*u = FlexibleUUID(uid)
+
+	return nil
+}
+
+func (u FlexibleUUID) MarshalJSON() ([]byte, error) {
+	return UUID(u).MarshalJSON()
+}
+
+func (u *FlexibleUUID) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		*u = FlexibleUUID(Nil)
+		return nil
+	}
+
+	str, err := strconv.Unquote(string(b))
+	if err != nil {
+		return errInvalid("invalid json value for uuid (must be string or null): " + string(b))
+	}
+
+	return u.UnmarshalText([]byte(str))
+}
@@ -0,0 +1,87 @@
+package uuid
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFlexibleUUIDJSON(t *testing.T) {
+	for _, data := range []struct {
+		original string
+		want     UUID
+	}{
+		{original: `{"id":"afe40693-8f63-4766-85f1-250a427f1db5"}`, want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{original: `{"id":"afe406938f63476685f1250a427f1db5"}`, want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{original: `{"id":"AFE406938F63476685F1250A427F1DB5"}`, want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{original: `{"id":"00000000000000000000000000000000"}`, want: Nil},
+		{original: `{"id":"{AFE40693-8F63-4766-85F1-250A427F1DB5}"}`, want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{original: `{"id":"urn:uuid:afe40693-8f63-4766-85f1-250a427f1db5"}`, want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{original: `{"id":"URN:UUID:AFE40693-8F63-4766-85F1-250A427F1DB5"}`, want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{original: `{"id":""}`, want: Nil},
+		{original: `{"id":null}`, want: Nil},
+	} {
+		// decode into a previously used value
+		req := struct {
+			ID FlexibleUUID `json:"id"`
+		}{ID: FlexibleUUID(NewV4())}
+
+		if err := json.Unmarshal([]byte(data.original), &req); err != nil {
+			t.Fatal(err)
+		}
+		if req.ID.UUID() != data.want {
+			t.Errorf("want: %v, got: %v", data.want, req.ID)
+		}
+
+		// marshalled in canonical format
+		b, err := json.Marshal(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := `{"id":"` + data.want.String() + `"}`; want != string(b) {
+			t.Errorf("want: %s, got: %s", want, b)
+		}
+	}
+}
+
+func TestFlexibleUUIDError(t *testing.T) {
+	for _, orig := range append([]string{
+		"afe40693-8f63-4766-85f1-250a427f1db",
+		"afe406938f63476685f1250a427f1db5a",
+		"afe406938f63-4766-85f1-250a427f1db5",
+		"afe406938f63476605f1250a427f1db5",
+		"{afe40693-8f63-4766-85f1-250a427f1db5",
+		"afe40693-8f63-4766-85f1-250a427f1db5}",
+		"{}",
+		"{{afe40693-8f63-4766-85f1-250a427f1db5}}",
+		"{afe406938f63476685f1250a427f1db5}",
+		"urn:uuid:",
+		"urn:uuid:{afe40693-8f63-4766-85f1-250a427f1db5}",
+		"urn:afe40693-8f63-4766-85f1-250a427f1db5",
+		" afe40693-8f63-4766-85f1-250a427f1db5",
+	}, testErrors...) {
+		// the only valid hash-like uuid of testErrors, rejected by UUID only
+		if orig == "afe406938f63476685f1250a427f1db5" {
+			continue
+		}
+
+		var u FlexibleUUID
+		if err := u.UnmarshalText([]byte(orig)); err == nil {
+			t.Errorf("expected error, but got nothing for %v", orig)
+		}
+		if err := json.Unmarshal([]byte(`"`+orig+`"`), &u); err == nil {
+			t.Errorf("expected error, but got nothing for %v", orig)
+		}
+	}
+
+	var u FlexibleUUID
+	if err := json.Unmarshal([]byte(`1`), &u); err == nil {
+		t.Error("expected error, but got nothing for number")
+	}
+}
+
+func TestUUIDRejectsHashLike(t *testing.T) {
+	var u UUID
+	if err := json.Unmarshal([]byte(`"afe406938f63476685f1250a427f1db5"`), &u); err == nil {
+		t.Error("expected error, but got nothing for hash-like uuid")
+	}
+}
@@ -0,0 +1,186 @@
+package uuid
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+const urnPrefix = "urn:uuid:"
+
+// URN returns the uuid as an RFC 4122 urn, eg: urn:uuid:afe40693-8f63-4766-85f1-250a427f1db5
+func (u UUID) URN() string {
+	if u == Nil {
+		return ""
+	}
+
+	return urnPrefix + u.String()
+}
+
+// FromURN parses uuid in urn format, eg: urn:uuid:afe40693-8f63-4766-85f1-250a427f1db5
+// The prefix is case-insensitive, the rest is validated as in FromString.
+func FromURN(str string) (UUID, error) {
+	if str == "" {
+		return Nil, nil
+	}
+
+	if !hasURNPrefix(str) {
+		return Nil, errInvalid("invalid uuid urn: " + str)
+	}
+
+	return fromWrapped(str, str[len(urnPrefix):])
+}
+
+func hasURNPrefix(str string) bool {
+	return len(str) >= len(urnPrefix) && strings.EqualFold(str[:len(urnPrefix)], urnPrefix)
+}
+
+// Braced returns the uuid in braced (GUID) format, eg: {afe40693-8f63-4766-85f1-250a427f1db5}
+func (u UUID) Braced() string {
+	if u == Nil {
+		return ""
+	}
+
+	return "{" + u.String() + "}"
+}
+
+// FromBraced parses uuid in braced (GUID) format, eg: {AFE40693-8F63-4766-85F1-250A427F1DB5}
+// The braces must surround a canonical uuid, which is validated as in FromString.
+func FromBraced(str string) (UUID, error) {
+	if str == "" {
+		return Nil, nil
+	}
+
+	if len(str) < 2 || str[0] != '{' || str[len(str)-1] != '}' {
+		return Nil, errInvalid("invalid braced uuid, unbalanced braces: " + str)
+	}
+
+	return fromWrapped(str, str[1:len(str)-1])
+}
+
+// CanonicalString returns the uuid in lowercase canonical format, unlike String it returns the zero uuid
+// (00000000-0000-0000-0000-000000000000) for Nil, eg: for systems which require an explicit value.
+func (u UUID) CanonicalString() string {
+	if u == Nil {
+		return "00000000-0000-0000-0000-000000000000"
+	}
+
+	return strings.ToLower(u.String())
+}
+
+// Upper returns the uuid in uppercase canonical format, eg: AFE40693-8F63-4766-85F1-250A427F1DB5
+func (u UUID) Upper() string {
+	return strings.ToUpper(u.String())
+}
+
+// UpperHashLike returns the uuid in uppercase hash format, eg: AFE406938F63476685F1250A427F1DB5
+// Returns an empty string for Nil and malformed uuids, see HashLike.
+func (u UUID) UpperHashLike() string {
+	return strings.ToUpper(u.HashLike())
+}
+
+// Format returns the uuid formatted according to the .NET Guid.ToString() format specifiers:
+// N: hash, eg: afe406938f63476685f1250a427f1db5
+// D: canonical, eg: afe40693-8f63-4766-85f1-250a427f1db5
+// B: braced, eg: {afe40693-8f63-4766-85f1-250a427f1db5}
+// P: parenthesized, eg: (afe40693-8f63-4766-85f1-250a427f1db5)
+// X: hex groups, eg: {0xafe40693,0x8f63,0x4766,{0x85,0xf1,0x25,0x0a,0x42,0x7f,0x1d,0xb5}}
+// The layout is case-insensitive, empty layout means D. Nil is formatted as an empty string in every layout.
+func (u UUID) Format(layout string) (string, error) {
+	if _, ok := formats[strings.ToUpper(layout)]; !ok && layout != "" {
+		return "", errors.New("unknown uuid format: " + layout)
+	}
+
+	if u == Nil {
+		return "", nil
+	}
+
+	b, err := u.decode()
+	if err != nil {
+		return "", errInvalid("invalid uuid: " + u.String())
+	}
+
+	return formatBytes(b, strings.ToUpper(layout)), nil
+}
+
+// ParseFormat parses uuid formatted according to the .NET Guid.ToString() format specifiers, see Format.
+// The input is case-insensitive, the version and variant bits are validated as in FromString.
+func ParseFormat(layout string, str string) (UUID, error) {
+	switch strings.ToUpper(layout) {
+	case "N":
+		return FromHashLike(str)
+	case "D", "":
+		return FromString(str)
+	case "B":
+		return FromBraced(str)
+	case "P":
+		if str == "" {
+			return Nil, nil
+		}
+		if len(str) < 2 || str[0] != '(' || str[len(str)-1] != ')' {
+			return Nil, errInvalid("invalid parenthesized uuid: " + str)
+		}
+		return fromWrapped(str, str[1:len(str)-1])
+	case "X":
+		if str == "" {
+			return Nil, nil
+		}
+
+		// strip the decoration, then check that it was exactly what Format would produce
+		hash := strings.NewReplacer("0x", "", "0X", "", "{", "", "}", "", ",", "").Replace(str)
+		u, err := FromHashLike(hash)
+		if err != nil || len(hash) != 32 {
+			return Nil, errInvalid("invalid hex groups uuid: " + str)
+		}
+		var b [size]byte
+		_, _ = hex.Decode(b[:], []byte(hash))
+		if !strings.EqualFold(formatBytes(b, "X"), str) {
+			return Nil, errInvalid("invalid hex groups uuid: " + str)
+		}
+
+		return u, nil
+	default:
+		return Nil, errors.New("unknown uuid format: " + layout)
+	}
+}
+
+var formats = map[string]struct{}{
+	"N": {},
+	"D": {},
+	"B": {},
+	"P": {},
+	"X": {},
+}
+
+func formatBytes(b [size]byte, layout string) string {
+	canonical := string(encodeBytes(b[:]))
+
+	switch layout {
+	case "N":
+		return hex.EncodeToString(b[:])
+	case "B":
+		return "{" + canonical + "}"
+	case "P":
+		return "(" + canonical + ")"
+	case "X":
+		var sb strings.Builder
+		sb.WriteString("{0x")
+		sb.WriteString(canonical[0:8])
+		sb.WriteString(",0x")
+		sb.WriteString(canonical[9:13])
+		sb.WriteString(",0x")
+		sb.WriteString(canonical[14:18])
+		sb.WriteString(",{")
+		for i := 8; i < size; i++ {
+			if i > 8 {
+				sb.WriteByte(',')
+			}
+			sb.WriteString("0x")
+			sb.WriteString(hex.EncodeToString(b[i : i+1]))
+		}
+		sb.WriteString("}}")
+		return sb.String()
+	default:
+		return canonical
+	}
+}
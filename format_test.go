@@ -0,0 +1,289 @@
+package uuid
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestURN(t *testing.T) {
+	for _, data := range []struct {
+		original UUID
+		want     string
+	}{
+		{original: "", want: ""},
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", want: "urn:uuid:afe40693-8f63-4766-85f1-250a427f1db5"},
+	} {
+		got := data.original.URN()
+		if data.want != got {
+			t.Errorf("want: %s, got: %s", data.want, got)
+		}
+
+		back, err := FromURN(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back != data.original {
+			t.Errorf("want: %s, got: %s", data.original, back)
+		}
+	}
+}
+
+func TestFromURN(t *testing.T) {
+	for _, data := range []struct {
+		original string
+		want     UUID
+	}{
+		{original: "", want: ""},
+		{original: "urn:uuid:00000000-0000-0000-0000-000000000000", want: ""},
+		{original: "urn:uuid:afe40693-8f63-4766-85f1-250a427f1db5", want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{original: "URN:UUID:AFE40693-8F63-4766-85F1-250A427F1DB5", want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{original: "Urn:Uuid:afe40693-8f63-4766-85f1-250a427f1db5", want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+	} {
+		got, err := FromURN(data.original)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if data.want != got {
+			t.Errorf("FromURN(%v) want: %s, got: %s", data.original, data.want, got)
+		}
+	}
+}
+
+func TestFromURNError(t *testing.T) {
+	for _, data := range []struct {
+		name     string
+		original string
+	}{
+		{name: "missing prefix", original: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{name: "wrong prefix", original: "urn:uid:afe40693-8f63-4766-85f1-250a427f1db5"},
+		{name: "prefix only", original: "urn:uuid:"},
+		{name: "trailing junk", original: "urn:uuid:afe40693-8f63-4766-85f1-250a427f1db5x"},
+		{name: "trailing space", original: "urn:uuid:afe40693-8f63-4766-85f1-250a427f1db5 "},
+		{name: "hash form", original: "urn:uuid:afe406938f63476685f1250a427f1db5"},
+		{name: "invalid version bit", original: "urn:uuid:99999999-9999-6999-9999-250a427f1db5"},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			_, err := FromURN(data.original)
+			if err == nil {
+				t.Errorf("expected error, but got nothing for %v", data.original)
+			}
+		})
+	}
+}
+
+func TestBraced(t *testing.T) {
+	for _, data := range []struct {
+		original UUID
+		want     string
+	}{
+		{original: "", want: ""},
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", want: "{afe40693-8f63-4766-85f1-250a427f1db5}"},
+	} {
+		got := data.original.Braced()
+		if data.want != got {
+			t.Errorf("want: %s, got: %s", data.want, got)
+		}
+
+		back, err := FromBraced(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back != data.original {
+			t.Errorf("want: %s, got: %s", data.original, back)
+		}
+	}
+}
+
+func TestFromBraced(t *testing.T) {
+	for _, data := range []struct {
+		original string
+		want     UUID
+	}{
+		{original: "", want: ""},
+		{original: "{00000000-0000-0000-0000-000000000000}", want: ""},
+		{original: "{afe40693-8f63-4766-85f1-250a427f1db5}", want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{original: "{AFE40693-8F63-4766-85F1-250A427F1DB5}", want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+	} {
+		got, err := FromBraced(data.original)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if data.want != got {
+			t.Errorf("FromBraced(%v) want: %s, got: %s", data.original, data.want, got)
+		}
+	}
+}
+
+func TestFromBracedError(t *testing.T) {
+	for _, data := range []struct {
+		name     string
+		original string
+	}{
+		{name: "no braces", original: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{name: "opening brace only", original: "{afe40693-8f63-4766-85f1-250a427f1db5"},
+		{name: "closing brace only", original: "afe40693-8f63-4766-85f1-250a427f1db5}"},
+		{name: "single brace", original: "{"},
+		{name: "empty braces", original: "{}"},
+		{name: "braced hash", original: "{afe406938f63476685f1250a427f1db5}"},
+		{name: "double braces", original: "{{afe40693-8f63-4766-85f1-250a427f1db5}}"},
+		{name: "invalid variant bit", original: "{99999999-9999-4999-1999-250a427f1db5}"},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			_, err := FromBraced(data.original)
+			if err == nil {
+				t.Errorf("expected error, but got nothing for %v", data.original)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	u := UUID("afe40693-8f63-4766-85f1-250a427f1db5")
+
+	for _, data := range []struct {
+		layout string
+		want   string
+	}{
+		{layout: "N", want: "afe406938f63476685f1250a427f1db5"},
+		{layout: "D", want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{layout: "", want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{layout: "B", want: "{afe40693-8f63-4766-85f1-250a427f1db5}"},
+		{layout: "P", want: "(afe40693-8f63-4766-85f1-250a427f1db5)"},
+		{layout: "X", want: "{0xafe40693,0x8f63,0x4766,{0x85,0xf1,0x25,0x0a,0x42,0x7f,0x1d,0xb5}}"},
+		{layout: "x", want: "{0xafe40693,0x8f63,0x4766,{0x85,0xf1,0x25,0x0a,0x42,0x7f,0x1d,0xb5}}"},
+	} {
+		t.Run(data.layout, func(t *testing.T) {
+			got, err := u.Format(data.layout)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if data.want != got {
+				t.Errorf("want: %s, got: %s", data.want, got)
+			}
+
+			back, err := ParseFormat(data.layout, got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if back != u {
+				t.Errorf("want: %s, got: %s", u, back)
+			}
+
+			back, err = ParseFormat(data.layout, strings.ToUpper(got))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if back != u {
+				t.Errorf("want: %s, got: %s", u, back)
+			}
+
+			nilStr, err := Nil.Format(data.layout)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if nilStr != "" {
+				t.Errorf("want empty string for Nil, got: %s", nilStr)
+			}
+		})
+	}
+}
+
+func TestFormatError(t *testing.T) {
+	if _, err := UUID("afe40693-8f63-4766-85f1-250a427f1db5").Format("Q"); err == nil {
+		t.Error("expected error, but got nothing for unknown layout")
+	}
+
+	if _, err := UUID("asda").Format("N"); err == nil {
+		t.Error("expected error, but got nothing for malformed uuid")
+	}
+}
+
+func TestParseFormatError(t *testing.T) {
+	for _, data := range []struct {
+		layout   string
+		original string
+	}{
+		{layout: "Q", original: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{layout: "N", original: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{layout: "D", original: "afe406938f63476685f1250a427f1db5"},
+		{layout: "B", original: "(afe40693-8f63-4766-85f1-250a427f1db5)"},
+		{layout: "P", original: "{afe40693-8f63-4766-85f1-250a427f1db5}"},
+		{layout: "P", original: "(afe40693-8f63-4766-85f1-250a427f1db5"},
+		{layout: "P", original: "()"},
+		{layout: "X", original: "afe406938f63476685f1250a427f1db5"},
+		{layout: "X", original: "{0xafe40693,0x8f63,0x4766,0x85f1,0x250a427f1db5}"},
+		{layout: "X", original: "{0xafe4069,0x38f63,0x4766,{0x85,0xf1,0x25,0x0a,0x42,0x7f,0x1d,0xb5}}"},
+		{layout: "X", original: "{0x99999999,0x9999,0x6999,{0x99,0x99,0x25,0x0a,0x42,0x7f,0x1d,0xb5}}"},
+	} {
+		t.Run(data.layout+" "+data.original, func(t *testing.T) {
+			_, err := ParseFormat(data.layout, data.original)
+			if err == nil {
+				t.Errorf("expected error, but got nothing for %v", data.original)
+			}
+		})
+	}
+}
+
+func TestCanonicalString(t *testing.T) {
+	for _, data := range []struct {
+		original UUID
+		want     string
+	}{
+		{original: Nil, want: "00000000-0000-0000-0000-000000000000"},
+		{original: "00000000-0000-0000-0000-000000000000", want: "00000000-0000-0000-0000-000000000000"},
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{original: "AFE40693-8F63-4766-85F1-250A427F1DB5", want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+	} {
+		if got := data.original.CanonicalString(); data.want != got {
+			t.Errorf("want: %s, got: %s", data.want, got)
+		}
+	}
+
+	if got := Nil.String(); got != "" {
+		t.Errorf("want empty string, got: %s", got)
+	}
+}
+
+func TestUpper(t *testing.T) {
+	for _, data := range []struct {
+		original      UUID
+		wantUpper     string
+		wantUpperHash string
+	}{
+		{
+			original:      "",
+			wantUpper:     "",
+			wantUpperHash: "",
+		},
+		{
+			original:      "afe40693-8f63-4766-85f1-250a427f1db5",
+			wantUpper:     "AFE40693-8F63-4766-85F1-250A427F1DB5",
+			wantUpperHash: "AFE406938F63476685F1250A427F1DB5",
+		},
+	} {
+		if got := data.original.Upper(); data.wantUpper != got {
+			t.Errorf("want: %s, got: %s", data.wantUpper, got)
+		}
+		if got := data.original.UpperHashLike(); data.wantUpperHash != got {
+			t.Errorf("want: %s, got: %s", data.wantUpperHash, got)
+		}
+
+		back, err := FromString(data.original.Upper())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back != data.original {
+			t.Errorf("want: %s, got: %s", data.original, back)
+		}
+
+		back, err = FromHashLike(data.original.UpperHashLike())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back != data.original {
+			t.Errorf("want: %s, got: %s", data.original, back)
+		}
+	}
+}
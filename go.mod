@@ -1,7 +1,12 @@
 module github.com/proemergotech/uuid
 
-go 1.17
+go 1.22
 
 require github.com/gofrs/uuid v3.0.0+incompatible
 
-require github.com/ugorji/go v1.1.1
+require (
+	github.com/ugorji/go v1.1.1
+	go.opentelemetry.io/otel/trace v1.31.0
+)
+
+require go.opentelemetry.io/otel v1.31.0 // indirect
@@ -0,0 +1,153 @@
+package uuid
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"slices"
+)
+
+// FromData generates a content addressed uuid from data: the same data always results in the same uuid.
+// The uuid is the first 16 bytes of the SHA-256 digest of data with the version, variant and time marker bits set
+// as in NewV4, leaving 121 bits of the digest. Collisions are as unlikely as for random v4 uuids, but the result
+// is only as secret as data itself.
+func FromData(data []byte) UUID {
+	digest := sha256.Sum256(data)
+
+	return fromDigest(digest[:])
+}
+
+// NewFromReader generates a content addressed uuid from everything read from r until EOF.
+// It streams the data through the hash, so the result is the same as FromData for the same bytes without
+// keeping them in memory. Read errors are returned as is.
+func NewFromReader(r io.Reader) (UUID, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return Nil, err
+	}
+
+	return fromDigest(h.Sum(nil)), nil
+}
+
+// Merge combines uuids into a new v4 uuid independently of their order: Merge(a, b, c) == Merge(c, a, b).
+// Unlike chained XOR calls, duplicates do not cancel out, so Merge(a, a, b) != Merge(b).
+// The uuids are hashed by their 16 bytes, so case is ignored. Nil and the all-zero uuid are skipped,
+// Merge returns Nil if there is nothing left to merge and an error for malformed uuids.
+func Merge(uuids ...UUID) (UUID, error) {
+	bs := make([][size]byte, 0, len(uuids))
+	for _, u := range uuids {
+		if u.IsNil() {
+			continue
+		}
+
+		b, err := u.Bytes()
+		if err != nil {
+			return Nil, err
+		}
+		bs = append(bs, b)
+	}
+
+	if len(bs) == 0 {
+		return Nil, nil
+	}
+
+	slices.SortFunc(bs, func(a, b [size]byte) int {
+		return bytes.Compare(a[:], b[:])
+	})
+
+	h := sha256.New()
+	for _, b := range bs {
+		h.Write(b[:])
+	}
+
+	return fromDigest(h.Sum(nil)), nil
+}
+
+// fromDigest truncates a hash digest (min 16 bytes) to a v4 uuid without the time marker.
+func fromDigest(digest []byte) UUID {
+	u := [size]byte{}
+	copy(u[:], digest)
+
+	setVersion(u[:])
+	u[8] &^= timeMarker
+
+	return UUID(string(encodeBytes(u[:])))
+}
+
+// Derive generates a child uuid from the uuid and a name, eg: the settings of a user from the user's uuid.
+// The same uuid and name always result in the same child, different names result in different children.
+// The child is the first 16 bytes of the SHA-256 digest of the 16 bytes of the uuid followed by name,
+// with the version, variant and time marker bits set as in NewV4. Returns an error for Nil and invalid uuids.
+func (u UUID) Derive(name string) (UUID, error) {
+	b, err := u.parentBytes()
+	if err != nil {
+		return Nil, err
+	}
+
+	h := sha256.New()
+	h.Write(b[:])
+	_, _ = io.WriteString(h, name)
+
+	return fromDigest(h.Sum(nil)), nil
+}
+
+// DeriveHMAC is like Derive, but the digest is HMAC-SHA256 keyed with key, so children can not be linked to
+// the uuid without the key, eg: to create stable pseudonymous ids. Changing the key, the uuid or context
+// changes the result. Returns an error for an empty key, Nil and invalid uuids.
+func DeriveHMAC(key []byte, u UUID, context string) (UUID, error) {
+	if len(key) == 0 {
+		return Nil, errors.New("empty key")
+	}
+
+	b, err := u.parentBytes()
+	if err != nil {
+		return Nil, err
+	}
+
+	h := hmac.New(sha256.New, key)
+	h.Write(b[:])
+	_, _ = io.WriteString(h, context)
+
+	return fromDigest(h.Sum(nil)), nil
+}
+
+// parentBytes validates the uuid as in FromString and returns its bytes, Nil results in an error.
+func (u UUID) parentBytes() ([size]byte, error) {
+	parent, err := FromString(u.String())
+	if err != nil {
+		return [size]byte{}, err
+	}
+	if parent == Nil {
+		return [size]byte{}, errors.New("missing uuid")
+	}
+
+	return parent.decode()
+}
+
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// Hash64 returns the 64 bits FNV-1a hash of the 16 bytes of the uuid, eg: for bloom filters or hash maps.
+// The result is the same across processes, architectures and releases. Returns 0 for Nil and malformed uuids.
+func (u UUID) Hash64() uint64 {
+	if u == Nil {
+		return 0
+	}
+
+	b, err := u.decode()
+	if err != nil {
+		return 0
+	}
+
+	h := uint64(fnvOffset64)
+	for _, c := range b {
+		h ^= uint64(c)
+		h *= fnvPrime64
+	}
+
+	return h
+}
@@ -0,0 +1,316 @@
+package uuid
+
+import (
+	"bytes"
+	"errors"
+	"hash/fnv"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+var dataTests = []struct {
+	data string
+	want UUID
+}{
+	{
+		data: "",
+		want: "e3b0c442-98fc-4c14-9afb-f4c8996fb924",
+	},
+	{
+		data: "hello world",
+		want: "b94d27b9-934d-4e08-852e-52d7da7dabfa",
+	},
+	{
+		data: "The quick brown fox jumps over the lazy dog",
+		want: "d7a8fbb3-07d7-4094-89ca-9abcb0082e4f",
+	},
+}
+
+func TestFromData(t *testing.T) {
+	for _, data := range dataTests {
+		got := FromData([]byte(data.data))
+		if data.want != got {
+			t.Errorf("FromData(%q) want: %s, got: %s", data.data, data.want, got)
+		}
+
+		if _, err := FromString(got.String()); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestFromDataConsistent(t *testing.T) {
+	a := FromData([]byte("document"))
+	b := FromData([]byte("document"))
+	if a != b {
+		t.Errorf("uuid is different, %v != %v", a, b)
+	}
+
+	c := FromData([]byte("document2"))
+	if a == c {
+		t.Errorf("uuid is the same for different data: %v", a)
+	}
+}
+
+func TestNewFromReader(t *testing.T) {
+	for _, data := range dataTests {
+		got, err := NewFromReader(strings.NewReader(data.data))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if data.want != got {
+			t.Errorf("NewFromReader(%q) want: %s, got: %s", data.data, data.want, got)
+		}
+	}
+}
+
+func TestNewFromReaderLarge(t *testing.T) {
+	data := make([]byte, 5<<20)
+	for i := range data {
+		data[i] = byte(i * 31)
+	}
+
+	got, err := NewFromReader(iotest.OneByteReader(io.LimitReader(bytes.NewReader(data), 1<<20)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := FromData(data[:1<<20]); want != got {
+		t.Errorf("want: %s, got: %s", want, got)
+	}
+
+	got, err = NewFromReader(iotest.HalfReader(bytes.NewReader(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := FromData(data); want != got {
+		t.Errorf("want: %s, got: %s", want, got)
+	}
+}
+
+func TestNewFromReaderError(t *testing.T) {
+	readErr := errors.New("read failed")
+
+	got, err := NewFromReader(io.MultiReader(strings.NewReader("partial"), iotest.ErrReader(readErr)))
+	if err != readErr {
+		t.Errorf("want: %v, got: %v", readErr, err)
+	}
+	if got != Nil {
+		t.Errorf("want: Nil, got: %s", got)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	a := UUID("afe40693-8f63-4766-85f1-250a427f1db5")
+	b := UUID("43ae2f25-802d-4aae-be57-b7acefe336ac")
+	c := UUID("b94d27b9-934d-4e08-a52e-52d7da7dabfa")
+
+	merge := func(uuids ...UUID) UUID {
+		t.Helper()
+		u, err := Merge(uuids...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return u
+	}
+
+	abc := merge(a, b, c)
+	if _, err := FromString(abc.String()); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, got := range []UUID{
+		merge(c, a, b),
+		merge(b, c, a),
+		merge(a, Nil, c, b, Nil),
+		merge(a, "00000000-0000-0000-0000-000000000000", c, b),
+		merge(UUID(strings.ToUpper(a.String())), b, c),
+	} {
+		if abc != got {
+			t.Errorf("want: %s, got: %s", abc, got)
+		}
+	}
+
+	for _, got := range []UUID{
+		merge(a, b),
+		merge(a, a, b, c),
+		merge(a, b, c, c),
+		merge(a),
+	} {
+		if abc == got {
+			t.Errorf("merge of different uuids is the same: %s", got)
+		}
+	}
+
+	if merge(a, a, b) == merge(b) {
+		t.Error("duplicates cancel out")
+	}
+}
+
+func TestMergeError(t *testing.T) {
+	a := UUID("afe40693-8f63-4766-85f1-250a427f1db5")
+
+	for _, data := range [][]UUID{
+		{"ab", "c"},
+		{"a", "bc"},
+		{a, "afe40693"},
+		{a, "afe406938f63476685f1250a427f1db5"},
+	} {
+		if _, err := Merge(data...); err == nil {
+			t.Errorf("expected error, but got nothing for %v", data)
+		}
+	}
+}
+
+func TestMergeNil(t *testing.T) {
+	for _, data := range [][]UUID{
+		{},
+		{Nil},
+		{Nil, Nil},
+	} {
+		got, err := Merge(data...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != Nil {
+			t.Errorf("want: Nil, got: %s", got)
+		}
+	}
+}
+
+func TestDerive(t *testing.T) {
+	for _, data := range []struct {
+		parent UUID
+		name   string
+		want   UUID
+	}{
+		{parent: "afe40693-8f63-4766-85f1-250a427f1db5", name: "settings", want: "e01dde10-8ac6-43ff-988b-2e21b3005758"},
+		{parent: "afe40693-8f63-4766-85f1-250a427f1db5", name: "profile", want: "80e11af0-cd48-4999-8963-6075cfdec7a8"},
+		{parent: "afe40693-8f63-4766-85f1-250a427f1db5", name: "", want: "3c36e94f-7aea-44d2-919c-d5c6390f7e1c"},
+		{parent: "AFE40693-8F63-4766-85F1-250A427F1DB5", name: "settings", want: "e01dde10-8ac6-43ff-988b-2e21b3005758"},
+	} {
+		got, err := data.parent.Derive(data.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data.want != got {
+			t.Errorf("Derive(%q) want: %s, got: %s", data.name, data.want, got)
+		}
+
+		if _, err := FromString(got.String()); err != nil {
+			t.Error(err)
+		}
+	}
+
+	other, err := UUID("43ae2f25-802d-4aae-be57-b7acefe336ac").Derive("settings")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other == "e01dde10-8ac6-43ff-988b-2e21b3005758" {
+		t.Errorf("uuid is the same for different parents: %v", other)
+	}
+}
+
+func TestDeriveError(t *testing.T) {
+	for _, data := range []struct {
+		name     string
+		original UUID
+	}{
+		{name: "nil", original: Nil},
+		{name: "zero", original: "00000000-0000-0000-0000-000000000000"},
+		{name: "malformed", original: "asda"},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			_, err := data.original.Derive("settings")
+			if err == nil {
+				t.Errorf("expected error, but got nothing for %v", data.original)
+			}
+		})
+	}
+}
+
+func TestDeriveHMAC(t *testing.T) {
+	for _, data := range []struct {
+		key     string
+		context string
+		want    UUID
+	}{
+		{key: "secret", context: "analytics", want: "dc4c9b02-7363-41d8-9728-a34fa8494d4c"},
+		{key: "secret", context: "billing", want: "ed596d1e-5842-423d-99c8-1109d40bd7cd"},
+		{key: "other", context: "analytics", want: "31079b08-94f1-4cc9-8951-dd6ee3031640"},
+	} {
+		got, err := DeriveHMAC([]byte(data.key), "afe40693-8f63-4766-85f1-250a427f1db5", data.context)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data.want != got {
+			t.Errorf("DeriveHMAC(%q, %q) want: %s, got: %s", data.key, data.context, data.want, got)
+		}
+
+		if _, err := FromString(got.String()); err != nil {
+			t.Error(err)
+		}
+	}
+
+	derived, err := UUID("afe40693-8f63-4766-85f1-250a427f1db5").Derive("analytics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := DeriveHMAC([]byte("secret"), "43ae2f25-802d-4aae-be57-b7acefe336ac", "analytics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, u := range []UUID{derived, other} {
+		if u == "dc4c9b02-7363-41d8-9728-a34fa8494d4c" {
+			t.Errorf("uuid is the same for different inputs: %v", u)
+		}
+	}
+}
+
+func TestDeriveHMACError(t *testing.T) {
+	for _, data := range []struct {
+		name     string
+		key      []byte
+		original UUID
+	}{
+		{name: "empty key", key: []byte{}, original: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{name: "nil", key: []byte("secret"), original: Nil},
+		{name: "malformed", key: []byte("secret"), original: "asda"},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			_, err := DeriveHMAC(data.key, data.original, "analytics")
+			if err == nil {
+				t.Errorf("expected error, but got nothing for %v", data.original)
+			}
+		})
+	}
+}
+
+func TestHash64(t *testing.T) {
+	for _, data := range []struct {
+		original UUID
+		want     uint64
+	}{
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", want: 0xbae6100bb3184176},
+		{original: "AFE40693-8F63-4766-85F1-250A427F1DB5", want: 0xbae6100bb3184176},
+		{original: "43ae2f25-802d-4aae-be57-b7acefe336ac", want: 0xf649abaeb4f3f3f3},
+		{original: Max, want: 0xd6607508f5a1e855},
+		{original: Nil, want: 0},
+		{original: "asda", want: 0},
+	} {
+		if got := data.original.Hash64(); got != data.want {
+			t.Errorf("Hash64(%v) want: %#x, got: %#x", data.original, data.want, got)
+		}
+	}
+
+	// must be the same as the standard library implementation
+	u := NewV4()
+	b, _ := u.Bytes()
+	h := fnv.New64a()
+	h.Write(b[:])
+	if want, got := h.Sum64(), u.Hash64(); want != got {
+		t.Errorf("Hash64(%v) want: %#x, got: %#x", u, want, got)
+	}
+}
@@ -0,0 +1,89 @@
+package uuid
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"strconv"
+)
+
+// HashUUID is a UUID which is marshalled in hash format, eg: afe406938f63476685f1250a427f1db5
+// for consumers which require ids without dashes. Both the hash and the canonical format are accepted
+// when unmarshalled. It is stored in the database like UUID.
+type HashUUID UUID
+
+func (u HashUUID) UUID() UUID {
+	return UUID(u)
+}
+
+func (u HashUUID) String() string {
+	return string(u)
+}
+
+// MarshalText returns u in hash format, an empty string for Nil. It returns the same error as UUID.MarshalText
+// for malformed uuids.
+func (u HashUUID) MarshalText() ([]byte, error) {
+	if err := UUID(u).validateMarshal(); err != nil {
+		return nil, err
+	}
+
+	return []byte(UUID(u).HashLike()), nil
+}
+
+func (u *HashUUID) UnmarshalText(text []byte) error {
+	str := string(text)
+
+	var uid UUID
+	var err error
+	if len(str) == 32 {
+		uid, err = FromHashLike(str)
+	} else {
+		uid, err = FromString(str)
+	}
+	if err != nil {
+		return err
+	}
+
+	*u = HashUUID(uid)
+
+	return nil
+}
+
+// MarshalJSON returns u in hash format as a json string, see MarshalText.
+func (u HashUUID) MarshalJSON() ([]byte, error) {
+	text, err := u.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(strconv.Quote(string(text))), nil
+}
+
+func (u *HashUUID) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		*u = HashUUID(Nil)
+		return nil
+	}
+
+	str, err := strconv.Unquote(string(b))
+	if err != nil {
+		return errInvalid("invalid json value for uuid (must be string or null): " + string(b))
+	}
+
+	return u.UnmarshalText([]byte(str))
+}
+
+func (u *HashUUID) UnmarshalBinary(data []byte) error {
+	return u.UnmarshalText(data)
+}
+
+func (u HashUUID) MarshalBinary() (data []byte, err error) {
+	return u.MarshalText()
+}
+
+func (u HashUUID) Value() (driver.Value, error) {
+	return UUID(u).Value()
+}
+
+func (u *HashUUID) Scan(src interface{}) error {
+	return (*UUID)(u).Scan(src)
+}
@@ -0,0 +1,112 @@
+package uuid
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ugorji/go/codec"
+)
+
+func TestHashUUIDJSON(t *testing.T) {
+	for _, data := range []struct {
+		original string
+		want     HashUUID
+		wantJSON string
+	}{
+		{
+			original: `{"id":"afe406938f63476685f1250a427f1db5"}`,
+			want:     "afe40693-8f63-4766-85f1-250a427f1db5",
+			wantJSON: `{"id":"afe406938f63476685f1250a427f1db5"}`,
+		},
+		{
+			original: `{"id":"AFE40693-8F63-4766-85F1-250A427F1DB5"}`,
+			want:     "afe40693-8f63-4766-85f1-250a427f1db5",
+			wantJSON: `{"id":"afe406938f63476685f1250a427f1db5"}`,
+		},
+		{original: `{"id":""}`, want: HashUUID(Nil), wantJSON: `{"id":""}`},
+		{original: `{"id":null}`, want: HashUUID(Nil), wantJSON: `{"id":""}`},
+	} {
+		req := struct {
+			ID HashUUID `json:"id"`
+		}{ID: HashUUID(NewV4())}
+
+		if err := json.Unmarshal([]byte(data.original), &req); err != nil {
+			t.Fatal(err)
+		}
+		if req.ID != data.want {
+			t.Errorf("want: %v, got: %v", data.want, req.ID)
+		}
+
+		b, err := json.Marshal(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data.wantJSON != string(b) {
+			t.Errorf("want: %s, got: %s", data.wantJSON, b)
+		}
+	}
+}
+
+func TestHashUUIDError(t *testing.T) {
+	for _, orig := range testErrors {
+		// the only valid hash-like uuid of testErrors
+		if orig == "afe406938f63476685f1250a427f1db5" {
+			continue
+		}
+
+		var u HashUUID
+		if err := u.UnmarshalText([]byte(orig)); err == nil {
+			t.Errorf("expected error, but got nothing for %v", orig)
+		}
+	}
+
+	if _, err := json.Marshal(HashUUID("asda")); err == nil {
+		t.Error("expected error, but got nothing for malformed uuid")
+	}
+}
+
+func TestHashUUIDMsgPack(t *testing.T) {
+	handle := &codec.MsgpackHandle{}
+
+	var b []byte
+	if err := codec.NewEncoderBytes(&b, handle).Encode(HashUUID("afe40693-8f63-4766-85f1-250a427f1db5")); err != nil {
+		t.Fatal(err)
+	}
+
+	var str string
+	if err := codec.NewDecoderBytes(b, handle).Decode(&str); err != nil {
+		t.Fatal(err)
+	}
+	if want := "afe406938f63476685f1250a427f1db5"; want != str {
+		t.Errorf("want: %v, got: %v", want, str)
+	}
+
+	var u HashUUID
+	if err := codec.NewDecoderBytes(b, handle).Decode(&u); err != nil {
+		t.Fatal(err)
+	}
+	if want := HashUUID("afe40693-8f63-4766-85f1-250a427f1db5"); want != u {
+		t.Errorf("want: %v, got: %v", want, u)
+	}
+}
+
+func TestHashUUIDSql(t *testing.T) {
+	orig := HashUUID("afe40693-8f63-4766-85f1-250a427f1db5")
+
+	v, err := orig.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var u HashUUID
+	if err := u.Scan(v); err != nil {
+		t.Fatal(err)
+	}
+	if u != orig {
+		t.Errorf("want: %v, got: %v", orig, u)
+	}
+
+	if v, err := HashUUID(Nil).Value(); err != nil || v != nil {
+		t.Errorf("want nil value for Nil, got: %v, %v", v, err)
+	}
+}
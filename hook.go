@@ -0,0 +1,35 @@
+package uuid
+
+import (
+	"sync/atomic"
+)
+
+// Kinds reported to the hook registered with OnGenerate.
+const (
+	KindV4         = "v4"
+	KindV4Insecure = "v4-insecure"
+	KindTime       = "time"
+	KindSequence   = "sequence"
+)
+
+var generateHook atomic.Pointer[func(u UUID, kind string)]
+
+// OnGenerate registers fn to be called after every generated uuid with the kind of uuid produced, eg: for metrics.
+// Only one hook can be registered, calling OnGenerate again replaces the previous one, passing nil removes it.
+// fn is called synchronously from the generating goroutine, so it must be safe for concurrent use and fast.
+func OnGenerate(fn func(u UUID, kind string)) {
+	if fn == nil {
+		generateHook.Store(nil)
+		return
+	}
+
+	generateHook.Store(&fn)
+}
+
+func generated(u UUID, kind string) UUID {
+	if fn := generateHook.Load(); fn != nil {
+		(*fn)(u, kind)
+	}
+
+	return u
+}
@@ -0,0 +1,70 @@
+package uuid
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOnGenerate(t *testing.T) {
+	defer OnGenerate(nil)
+
+	var mu sync.Mutex
+	counts := make(map[string]int)
+	OnGenerate(func(u UUID, kind string) {
+		if _, err := FromString(u.String()); err != nil {
+			t.Error(err)
+		}
+
+		mu.Lock()
+		counts[kind]++
+		mu.Unlock()
+	})
+
+	for i := 0; i < 3; i++ {
+		NewV4()
+	}
+	for i := 0; i < 2; i++ {
+		NewTime(time.Now())
+	}
+	NewV4Insecure()
+
+	for kind, want := range map[string]int{
+		KindV4:         3,
+		KindTime:       2,
+		KindV4Insecure: 1,
+	} {
+		if got := counts[kind]; got != want {
+			t.Errorf("kind %v, want: %v calls, got: %v", kind, want, got)
+		}
+	}
+
+	OnGenerate(nil)
+	NewV4()
+	if got := counts[KindV4]; got != 3 {
+		t.Errorf("hook called after removal, calls: %v", got)
+	}
+}
+
+func TestOnGenerateConcurrent(t *testing.T) {
+	defer OnGenerate(nil)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				NewV4()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				OnGenerate(func(u UUID, kind string) {})
+				OnGenerate(nil)
+			}
+		}()
+	}
+	wg.Wait()
+}
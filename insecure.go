@@ -0,0 +1,24 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"math/rand/v2"
+)
+
+// NewV4Insecure generates a v4 uuid from math/rand/v2 instead of crypto/rand.
+// @warning - The result is predictable, never use it for real identifiers.
+// It is meant for simulations and load tests where generation speed matters more than unpredictability.
+func NewV4Insecure() UUID {
+	u := [size]byte{}
+	binary.BigEndian.PutUint64(u[0:8], rand.Uint64())
+	binary.BigEndian.PutUint64(u[8:16], rand.Uint64())
+
+	setVersion(u[:])
+	u[8] &^= timeMarker
+
+	// encode on the stack so the only allocation is the returned string
+	buf := [36]byte{}
+	encodeInto(buf[:], u[:])
+
+	return generated(UUID(buf[:]), KindV4Insecure)
+}
@@ -0,0 +1,49 @@
+package uuid
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+)
+
+func TestNewV4Insecure(t *testing.T) {
+	const max = 100000
+
+	uuids := make(map[UUID]struct{}, max)
+	for i := 0; i < max; i++ {
+		u := NewV4Insecure()
+		if _, ok := uuids[u]; ok {
+			t.Errorf("NewV4Insecure returned same uuid twice: %s", u)
+		}
+		uuids[u] = struct{}{}
+
+		if _, err := FromString(u.String()); err != nil {
+			t.Error(err)
+		}
+
+		uid, err := uuid.FromString(u.String())
+		if err != nil {
+			t.Error(err)
+		}
+
+		if uuid.V4 != uid.Version() {
+			t.Errorf("invalid version in generated uuid: %s, expected: %v got: %v", u.String(), uuid.V4, uid.Version())
+		}
+
+		if uuid.VariantRFC4122 != uid.Variant() {
+			t.Errorf("invalid variant in generated uuid: %s, expected: %v got: %v", u.String(), uuid.VariantRFC4122, uid.Variant())
+		}
+	}
+}
+
+func BenchmarkNewV4(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = NewV4()
+	}
+}
+
+func BenchmarkNewV4Insecure(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = NewV4Insecure()
+	}
+}
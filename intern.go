@@ -0,0 +1,98 @@
+package uuid
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+const internShards = 64
+
+type internShard struct {
+	mu     sync.RWMutex
+	values map[UUID]UUID
+}
+
+var (
+	internTable [internShards]internShard
+	internSize  atomic.Int64
+	internLimit atomic.Int64
+)
+
+func init() {
+	internLimit.Store(-1)
+}
+
+// Intern returns a uuid equal to u which shares its backing storage with every other interned copy of u,
+// eg: to save memory in large indexes holding many copies of the same uuids. It is safe for concurrent use.
+// The table holds a copy of u, so substrings of bigger buffers do not keep those alive.
+// Once the table holds as many uuids as set by SetInternLimit, new uuids are returned as is.
+// Nil is returned as is.
+func Intern(u UUID) UUID {
+	if u == Nil {
+		return Nil
+	}
+
+	s := &internTable[internHash(u)%internShards]
+
+	s.mu.RLock()
+	v, ok := s.values[u]
+	s.mu.RUnlock()
+	if ok {
+		return v
+	}
+
+	if limit := internLimit.Load(); limit >= 0 && internSize.Load() >= limit {
+		return u
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if v, ok := s.values[u]; ok {
+		return v
+	}
+	if s.values == nil {
+		s.values = make(map[UUID]UUID)
+	}
+	// copy u, it may be a substring of a bigger buffer which must not be kept alive by the table
+	v = UUID(strings.Clone(string(u)))
+	s.values[v] = v
+	internSize.Add(1)
+
+	return v
+}
+
+// SetInternLimit sets the maximum number of uuids held by the Intern table, a negative limit (the default)
+// means no limit. Uuids already in the table are kept, see ResetIntern.
+func SetInternLimit(limit int) {
+	internLimit.Store(int64(limit))
+}
+
+// ResetIntern removes every uuid from the Intern table, so memory is only held by the interned copies in use.
+func ResetIntern() {
+	for i := range internTable {
+		s := &internTable[i]
+
+		s.mu.Lock()
+		internSize.Add(-int64(len(s.values)))
+		s.values = nil
+		s.mu.Unlock()
+	}
+}
+
+// InternSize returns the number of uuids in the Intern table.
+func InternSize() int {
+	return int(internSize.Load())
+}
+
+// internHash is FNV-1a over the characters of the uuid, cheaper than decoding it first.
+func internHash(u UUID) uint64 {
+	h := uint64(fnvOffset64)
+	for i := 0; i < len(u); i++ {
+		h ^= uint64(u[i])
+		h *= fnvPrime64
+	}
+
+	return h
+}
@@ -0,0 +1,146 @@
+package uuid
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"unsafe"
+)
+
+func TestIntern(t *testing.T) {
+	defer ResetIntern()
+	ResetIntern()
+
+	a := UUID(strings.Clone("afe40693-8f63-4766-85f1-250a427f1db5"))
+	b := UUID(strings.Clone("afe40693-8f63-4766-85f1-250a427f1db5"))
+	if unsafe.StringData(string(a)) == unsafe.StringData(string(b)) {
+		t.Fatal("expected different backing storage before interning")
+	}
+
+	ia, ib := Intern(a), Intern(b)
+	if ia != a || ib != b {
+		t.Errorf("interned values are different: %v, %v", ia, ib)
+	}
+	if unsafe.StringData(string(ia)) != unsafe.StringData(string(ib)) {
+		t.Error("expected the same backing storage after interning")
+	}
+
+	if got := Intern(Nil); got != Nil {
+		t.Errorf("want Nil, got: %v", got)
+	}
+
+	if got := InternSize(); got != 1 {
+		t.Errorf("want: 1, got: %v", got)
+	}
+
+	ResetIntern()
+	if got := InternSize(); got != 0 {
+		t.Errorf("want: 0, got: %v", got)
+	}
+	if ic := Intern(b); unsafe.StringData(string(ic)) == unsafe.StringData(string(ia)) {
+		t.Error("expected a new entry after reset")
+	}
+}
+
+func TestInternCopy(t *testing.T) {
+	defer ResetIntern()
+	ResetIntern()
+
+	line := strings.Clone(`{"id":"afe40693-8f63-4766-85f1-250a427f1db5","name":"test"}`)
+	u := UUID(line[7:43])
+
+	iu := Intern(u)
+	if iu != u {
+		t.Errorf("want: %v, got: %v", u, iu)
+	}
+	if unsafe.StringData(string(iu)) == unsafe.StringData(string(u)) {
+		t.Error("expected the interned uuid not to alias its input")
+	}
+	if got := Intern(UUID(strings.Clone(string(u)))); unsafe.StringData(string(got)) != unsafe.StringData(string(iu)) {
+		t.Error("expected the same backing storage after interning")
+	}
+}
+
+func TestInternLimit(t *testing.T) {
+	defer SetInternLimit(-1)
+	defer ResetIntern()
+	ResetIntern()
+
+	SetInternLimit(2)
+	for i := 0; i < 10; i++ {
+		u := NewV4()
+		if got := Intern(u); got != u {
+			t.Errorf("want: %v, got: %v", u, got)
+		}
+	}
+
+	if got := InternSize(); got != 2 {
+		t.Errorf("want: 2, got: %v", got)
+	}
+}
+
+func TestInternConcurrent(t *testing.T) {
+	defer ResetIntern()
+	ResetIntern()
+
+	uuids := make([]UUID, 100)
+	for i := range uuids {
+		uuids[i] = NewV4()
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, u := range uuids {
+				if got := Intern(UUID(strings.Clone(string(u)))); got != u {
+					t.Errorf("want: %v, got: %v", u, got)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := InternSize(); got != len(uuids) {
+		t.Errorf("want: %v, got: %v", len(uuids), got)
+	}
+}
+
+// benchmarkRetained keeps 100000 copies of 1000 distinct uuids and reports the heap they retain.
+func benchmarkRetained(b *testing.B, intern func(UUID) UUID) {
+	distinct := make([]string, 1000)
+	for i := range distinct {
+		distinct[i] = NewV4().String()
+	}
+
+	for i := 0; i < b.N; i++ {
+		ResetIntern()
+
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+
+		kept := make([]UUID, 100000)
+		for j := range kept {
+			kept[j] = intern(UUID(strings.Clone(distinct[j%len(distinct)])))
+		}
+
+		runtime.GC()
+		runtime.ReadMemStats(&after)
+		b.ReportMetric(float64(int64(after.HeapAlloc)-int64(before.HeapAlloc)), "retained-B/op")
+
+		runtime.KeepAlive(kept)
+	}
+
+	ResetIntern()
+}
+
+func BenchmarkRetainedWithoutIntern(b *testing.B) {
+	benchmarkRetained(b, func(u UUID) UUID { return u })
+}
+
+func BenchmarkRetainedWithIntern(b *testing.B) {
+	benchmarkRetained(b, Intern)
+}
@@ -0,0 +1,123 @@
+package uuid
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseList parses a list of uuids in canonical format separated by sep, eg: a comma separated query parameter.
+// An empty string results in an empty list. Every element is validated as in FromString, empty elements
+// (eg: caused by a trailing separator) and whitespace around the elements are rejected.
+// The error contains the index of the first invalid element.
+func ParseList(str string, sep string) ([]UUID, error) {
+	if sep == "" {
+		return nil, errors.New("empty uuid list separator")
+	}
+
+	if str == "" {
+		return []UUID{}, nil
+	}
+
+	parts := strings.Split(str, sep)
+	uuids := make([]UUID, 0, len(parts))
+	for i, part := range parts {
+		if part == "" {
+			return nil, errInvalid("invalid uuid list, empty element at index " + strconv.Itoa(i))
+		}
+
+		u, err := FromString(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid uuid list, element at index %d: %w", i, err)
+		}
+
+		uuids = append(uuids, u)
+	}
+
+	return uuids, nil
+}
+
+// JoinList returns the uuids in canonical format separated by sep, the reverse of ParseList.
+// Nil uuids result in empty elements, which ParseList rejects.
+func JoinList(uuids []UUID, sep string) string {
+	strs := make([]string, 0, len(uuids))
+	for _, u := range uuids {
+		strs = append(strs, u.String())
+	}
+
+	return strings.Join(strs, sep)
+}
+
+// FromStrings parses every element of strs in canonical format as in FromString.
+// The result always has the same length as strs, invalid elements are Nil.
+// If any of the elements is invalid, the error is a *BulkError containing every failing index.
+func FromStrings(strs []string) ([]UUID, error) {
+	uuids := make([]UUID, len(strs))
+	var bulkErr *BulkError
+	for i, str := range strs {
+		u, err := FromString(str)
+		if err != nil {
+			if bulkErr == nil {
+				bulkErr = &BulkError{}
+			}
+			bulkErr.Errors = append(bulkErr.Errors, &IndexError{Index: i, Err: err})
+			continue
+		}
+
+		uuids[i] = u
+	}
+
+	if bulkErr != nil {
+		return uuids, bulkErr
+	}
+
+	return uuids, nil
+}
+
+// BulkError is returned by bulk operations, it contains an error for each failed element in index order.
+type BulkError struct {
+	Errors []*IndexError
+}
+
+func (e *BulkError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Error())
+	}
+
+	return strconv.Itoa(len(e.Errors)) + " invalid uuids: " + strings.Join(msgs, "; ")
+}
+
+// Indices returns the indices of the failed elements.
+func (e *BulkError) Indices() []int {
+	indices := make([]int, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		indices = append(indices, err.Index)
+	}
+
+	return indices
+}
+
+func (e *BulkError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// IndexError is the error of a single element of a bulk operation.
+type IndexError struct {
+	Index int
+	Err   error
+}
+
+func (e *IndexError) Error() string {
+	return "index " + strconv.Itoa(e.Index) + ": " + e.Err.Error()
+}
+
+func (e *IndexError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,149 @@
+package uuid
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseList(t *testing.T) {
+	a := UUID("afe40693-8f63-4766-85f1-250a427f1db5")
+	b := UUID("43ae2f25-802d-4aae-be57-b7acefe336ac")
+
+	for _, data := range []struct {
+		original string
+		sep      string
+		want     []UUID
+	}{
+		{original: "", sep: ",", want: []UUID{}},
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", sep: ",", want: []UUID{a}},
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5,43ae2f25-802d-4aae-be57-b7acefe336ac", sep: ",", want: []UUID{a, b}},
+		{original: "AFE40693-8F63-4766-85F1-250A427F1DB5;43ae2f25-802d-4aae-be57-b7acefe336ac", sep: ";", want: []UUID{a, b}},
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5, 43ae2f25-802d-4aae-be57-b7acefe336ac", sep: ", ", want: []UUID{a, b}},
+		{original: "00000000-0000-0000-0000-000000000000,afe40693-8f63-4766-85f1-250a427f1db5", sep: ",", want: []UUID{Nil, a}},
+	} {
+		got, err := ParseList(data.original, data.sep)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(data.want, got) {
+			t.Errorf("ParseList(%v) want: %v, got: %v", data.original, data.want, got)
+		}
+	}
+}
+
+func TestParseListError(t *testing.T) {
+	for _, data := range []struct {
+		name      string
+		original  string
+		sep       string
+		wantIndex string
+	}{
+		{name: "invalid element", original: "afe40693-8f63-4766-85f1-250a427f1db5,asda", sep: ",", wantIndex: "index 1"},
+		{name: "trailing separator", original: "afe40693-8f63-4766-85f1-250a427f1db5,", sep: ",", wantIndex: "index 1"},
+		{name: "leading separator", original: ",afe40693-8f63-4766-85f1-250a427f1db5", sep: ",", wantIndex: "index 0"},
+		{name: "separator only", original: ",", sep: ",", wantIndex: "index 0"},
+		{name: "whitespace", original: "afe40693-8f63-4766-85f1-250a427f1db5, 43ae2f25-802d-4aae-be57-b7acefe336ac", sep: ",", wantIndex: "index 1"},
+		{name: "first invalid reported", original: "afe40693-8f63-4766-85f1-250a427f1db5,x,y", sep: ",", wantIndex: "index 1"},
+		{name: "empty separator", original: "afe40693-8f63-4766-85f1-250a427f1db5", sep: "", wantIndex: ""},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			_, err := ParseList(data.original, data.sep)
+			if err == nil {
+				t.Fatalf("expected error, but got nothing for %v", data.original)
+			}
+
+			if !strings.Contains(err.Error(), data.wantIndex) {
+				t.Errorf("expected error to contain %v, got: %v", data.wantIndex, err)
+			}
+		})
+	}
+}
+
+func TestJoinList(t *testing.T) {
+	uuids := []UUID{
+		"afe40693-8f63-4766-85f1-250a427f1db5",
+		"43ae2f25-802d-4aae-be57-b7acefe336ac",
+	}
+
+	if got := JoinList(nil, ","); got != "" {
+		t.Errorf("want empty string, got: %v", got)
+	}
+
+	got := JoinList(uuids, ",")
+	if want := "afe40693-8f63-4766-85f1-250a427f1db5,43ae2f25-802d-4aae-be57-b7acefe336ac"; want != got {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+
+	back, err := ParseList(got, ",")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(uuids, back) {
+		t.Errorf("want: %v, got: %v", uuids, back)
+	}
+}
+
+func TestFromStrings(t *testing.T) {
+	got, err := FromStrings([]string{
+		"afe40693-8f63-4766-85f1-250a427f1db5",
+		"",
+		"43AE2F25-802D-4AAE-BE57-B7ACEFE336AC",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []UUID{"afe40693-8f63-4766-85f1-250a427f1db5", Nil, "43ae2f25-802d-4aae-be57-b7acefe336ac"}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+
+	got, err = FromStrings(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("want empty slice, got: %v", got)
+	}
+}
+
+func TestFromStringsError(t *testing.T) {
+	got, err := FromStrings([]string{
+		"asda",
+		"afe40693-8f63-4766-85f1-250a427f1db5",
+		"99999999-9999-6999-9999-250a427f1db5",
+		"43ae2f25-802d-4aae-be57-b7acefe336ac",
+		"afe406938f63476685f1250a427f1db5",
+	})
+	if err == nil {
+		t.Fatal("expected error, but got nothing")
+	}
+
+	want := []UUID{Nil, "afe40693-8f63-4766-85f1-250a427f1db5", Nil, "43ae2f25-802d-4aae-be57-b7acefe336ac", Nil}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+
+	var bulkErr *BulkError
+	if !errors.As(err, &bulkErr) {
+		t.Fatalf("expected BulkError, got: %T", err)
+	}
+
+	if indices := bulkErr.Indices(); !reflect.DeepEqual([]int{0, 2, 4}, indices) {
+		t.Errorf("want: %v, got: %v", []int{0, 2, 4}, indices)
+	}
+
+	for _, indexErr := range bulkErr.Errors {
+		if !strings.Contains(indexErr.Err.Error(), "invalid uuid") {
+			t.Errorf("unexpected error for index %v: %v", indexErr.Index, indexErr.Err)
+		}
+	}
+
+	var indexErr *IndexError
+	if !errors.As(err, &indexErr) || indexErr.Index != 0 {
+		t.Errorf("expected first IndexError through Unwrap, got: %v", indexErr)
+	}
+}
@@ -0,0 +1,49 @@
+package uuid
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// NullableUUID is a UUID which is marshalled as json null instead of "" when Nil, eg: for fields declared
+// nullable in an OpenAPI schema. It is unmarshalled like UUID: null, "" and the zero uuid result in Nil.
+type NullableUUID UUID
+
+func (u NullableUUID) UUID() UUID {
+	return UUID(u)
+}
+
+func (u NullableUUID) String() string {
+	return string(u)
+}
+
+func (u NullableUUID) MarshalText() ([]byte, error) {
+	return UUID(u).MarshalText()
+}
+
+func (u *NullableUUID) UnmarshalText(text []byte) error {
+	return (*UUID)(u).UnmarshalText(text)
+}
+
+// MarshalJSON returns null for Nil (and the zero uuid), otherwise the same as UUID.MarshalJSON.
+func (u NullableUUID) MarshalJSON() ([]byte, error) {
+	if UUID(u).IsNil() {
+		return []byte("null"), nil
+	}
+
+	return UUID(u).MarshalJSON()
+}
+
+func (u *NullableUUID) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		*u = NullableUUID(Nil)
+		return nil
+	}
+
+	str, err := strconv.Unquote(string(b))
+	if err != nil {
+		return errInvalid("invalid json value for uuid (must be string or null): " + string(b))
+	}
+
+	return u.UnmarshalText([]byte(str))
+}
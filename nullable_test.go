@@ -0,0 +1,75 @@
+package uuid
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNullableUUIDJSON(t *testing.T) {
+	for _, data := range []struct {
+		original NullableUUID
+		want     string
+	}{
+		{original: NullableUUID(Nil), want: `{"id":null}`},
+		{original: "00000000-0000-0000-0000-000000000000", want: `{"id":null}`},
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", want: `{"id":"afe40693-8f63-4766-85f1-250a427f1db5"}`},
+	} {
+		req := struct {
+			ID NullableUUID `json:"id"`
+		}{ID: data.original}
+
+		b, err := json.Marshal(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data.want != string(b) {
+			t.Errorf("want: %s, got: %s", data.want, b)
+		}
+	}
+
+	// the UUID type is unchanged
+	b, err := json.Marshal(Nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `""`; want != string(b) {
+		t.Errorf("want: %s, got: %s", want, b)
+	}
+}
+
+func TestNullableUUIDUnmarshalJSON(t *testing.T) {
+	for _, data := range []struct {
+		original string
+		want     UUID
+	}{
+		{original: `{"id":null}`, want: Nil},
+		{original: `{"id":""}`, want: Nil},
+		{original: `{"id":"00000000-0000-0000-0000-000000000000"}`, want: Nil},
+		{original: `{"id":"AFE40693-8F63-4766-85F1-250A427F1DB5"}`, want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+	} {
+		// decode into a previously used value
+		req := struct {
+			ID NullableUUID `json:"id"`
+		}{ID: NullableUUID(NewV4())}
+
+		if err := json.Unmarshal([]byte(data.original), &req); err != nil {
+			t.Fatal(err)
+		}
+		if req.ID.UUID() != data.want {
+			t.Errorf("want: %v, got: %v", data.want, req.ID)
+		}
+	}
+
+	for _, data := range []string{
+		`{"id":"asda"}`,
+		`{"id":1}`,
+		`{"id":"afe406938f63476685f1250a427f1db5"}`,
+	} {
+		var req struct {
+			ID NullableUUID `json:"id"`
+		}
+		if err := json.Unmarshal([]byte(data), &req); err == nil {
+			t.Errorf("expected error, but got nothing for %v", data)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package uuid
+
+import (
+	"errors"
+)
+
+// obfuscateCheckMask covers the 58 bits of the plaintext which must be zero after decryption.
+const obfuscateCheckMask = 1<<58 - 1
+
+// ObfuscateInt64 maps an integer id (eg: an auto increment primary key) into a random looking version 4 uuid.
+// The mapping is a keyed permutation, so it is deterministic, different ids never result in the same uuid,
+// and the id can only be recovered with the same key, see DeobfuscateInt64.
+// Returns an error for an empty key.
+// @warning - The key must be kept secret, a guessable key provides no protection.
+func ObfuscateInt64(key []byte, id int64) (UUID, error) {
+	if len(key) == 0 {
+		return Nil, errors.New("empty key")
+	}
+
+	l, r := newFeistel(key, "int64").encrypt(uint64(id)>>3, uint64(id)&7<<58)
+
+	orig := [size]byte{}
+	orig[6] = 0x40
+	orig[8] = 0x80
+	b := joinFree(l, r, orig)
+
+	return UUID(string(encodeBytes(b[:]))), nil
+}
+
+// DeobfuscateInt64 returns the id which was mapped into the uuid by ObfuscateInt64.
+// The uuid carries a 58 bits integrity check, so uuids created with a different key, modified or
+// not created by ObfuscateInt64 result in an error instead of a wrong id. Returns an error for an empty key.
+func DeobfuscateInt64(key []byte, u UUID) (int64, error) {
+	if len(key) == 0 {
+		return 0, errors.New("empty key")
+	}
+
+	b, err := u.decode()
+	if err != nil || b[6]&0xf0 != 0x40 || b[8]&0xc0 != 0x80 {
+		return 0, errInvalid("invalid uuid: " + u.String())
+	}
+
+	l, r := newFeistel(key, "int64").decrypt(splitFree(b))
+	if r&obfuscateCheckMask != 0 {
+		return 0, errInvalid("invalid obfuscated uuid: " + u.String())
+	}
+
+	return int64(l<<3 | r>>58), nil
+}
+
+// Encrypt encrypts the uuid into another uuid with the same version and variant, eg: to pseudonymize primary keys.
+// The 122 bits which are not version or variant bits are encrypted with a keyed permutation (an 8 rounds Feistel
+// network using HMAC-SHA256 with the tweak "uuid" as round function), so the result is validated as in FromString and can be
+// converted back with Decrypt and the same key. Returns an error for an empty key, Nil and malformed uuids.
+func Encrypt(key []byte, u UUID) (UUID, error) {
+	b, err := cryptBytes(key, u)
+	if err != nil {
+		return Nil, err
+	}
+
+	l, r := newFeistel(key, "uuid").encrypt(splitFree(b))
+	b = joinFree(l, r, b)
+
+	return UUID(string(encodeBytes(b[:]))), nil
+}
+
+// Decrypt decrypts a uuid encrypted by Encrypt with the same key.
+// @warning - There is no integrity check: decrypting with a wrong key results in a different, valid uuid.
+func Decrypt(key []byte, u UUID) (UUID, error) {
+	b, err := cryptBytes(key, u)
+	if err != nil {
+		return Nil, err
+	}
+
+	l, r := newFeistel(key, "uuid").decrypt(splitFree(b))
+	b = joinFree(l, r, b)
+
+	return UUID(string(encodeBytes(b[:]))), nil
+}
+
+func cryptBytes(key []byte, u UUID) ([size]byte, error) {
+	if len(key) == 0 {
+		return [size]byte{}, errors.New("empty key")
+	}
+
+	v, err := FromString(u.String())
+	if err != nil || v.IsMax() {
+		return [size]byte{}, errInvalid("invalid uuid: " + u.String())
+	}
+	if v == Nil {
+		return [size]byte{}, errors.New("missing uuid")
+	}
+
+	return v.decode()
+}
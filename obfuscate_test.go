@@ -0,0 +1,191 @@
+package uuid
+
+import (
+	"math"
+	"testing"
+)
+
+var obfuscateKey = []byte("0123456789abcdef")
+
+func mustObfuscateInt64(t *testing.T, key []byte, id int64) UUID {
+	t.Helper()
+
+	u, err := ObfuscateInt64(key, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return u
+}
+
+func TestObfuscateInt64(t *testing.T) {
+	seen := make(map[UUID]int64)
+
+	for _, id := range []int64{0, 1, 2, 3, 1000, 1001, math.MaxInt64, math.MinInt64, -1} {
+		u := mustObfuscateInt64(t, obfuscateKey, id)
+		if _, err := FromStringV4(u.String()); err != nil {
+			t.Error(err)
+		}
+
+		if other, ok := seen[u]; ok {
+			t.Errorf("%v and %v both resulted in %v", id, other, u)
+		}
+		seen[u] = id
+
+		if again := mustObfuscateInt64(t, obfuscateKey, id); again != u {
+			t.Errorf("want: %v, got: %v", u, again)
+		}
+
+		back, err := DeobfuscateInt64(obfuscateKey, u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back != id {
+			t.Errorf("want: %v, got: %v", id, back)
+		}
+	}
+}
+
+func TestObfuscateInt64Key(t *testing.T) {
+	u := mustObfuscateInt64(t, obfuscateKey, 42)
+
+	if other := mustObfuscateInt64(t, []byte("fedcba9876543210"), 42); other == u {
+		t.Errorf("different keys resulted in the same uuid: %v", u)
+	}
+
+	if id, err := DeobfuscateInt64([]byte("fedcba9876543210"), u); err == nil {
+		t.Errorf("expected error, but got %v for wrong key", id)
+	}
+}
+
+func TestObfuscateInt64EmptyKey(t *testing.T) {
+	for _, key := range [][]byte{nil, {}} {
+		if u, err := ObfuscateInt64(key, 42); err == nil {
+			t.Errorf("expected error, but got %v for %q", u, key)
+		}
+
+		if id, err := DeobfuscateInt64(key, "afe40693-8f63-4766-85f1-250a427f1db5"); err == nil {
+			t.Errorf("expected error, but got %v for %q", id, key)
+		}
+	}
+}
+
+func TestDeobfuscateInt64Error(t *testing.T) {
+	u := mustObfuscateInt64(t, obfuscateKey, 42)
+	b, err := u.decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// flipping any of the free bits must be detected
+	for i := 0; i < size*8; i++ {
+		if i >= 48 && i < 52 || i >= 64 && i < 66 {
+			continue
+		}
+
+		tampered := b
+		tampered[i/8] ^= 0x80 >> (i % 8)
+		v := UUID(string(encodeBytes(tampered[:])))
+
+		if id, err := DeobfuscateInt64(obfuscateKey, v); err == nil {
+			t.Errorf("expected error, but got %v for %v", id, v)
+		}
+	}
+
+	for _, data := range []struct {
+		name     string
+		original UUID
+	}{
+		{name: "nil", original: Nil},
+		{name: "malformed", original: "asda"},
+		{name: "wrong version", original: UUID(u[:14] + "1" + u[15:])},
+		{name: "random", original: "afe40693-8f63-4766-85f1-250a427f1db5"},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			_, err := DeobfuscateInt64(obfuscateKey, data.original)
+			if err == nil {
+				t.Errorf("expected error, but got nothing for %v", data.original)
+			}
+		})
+	}
+}
+
+func BenchmarkObfuscateInt64(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = ObfuscateInt64(obfuscateKey, int64(i))
+	}
+}
+
+func TestEncrypt(t *testing.T) {
+	// vectors for the key "0123456789abcdef", see the feistel type for the exact algorithm
+	for _, data := range []struct {
+		original UUID
+		want     UUID
+	}{
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", want: "12128c4b-ff9e-42f1-9d06-ab3c852424b1"},
+		{original: "00000000-0000-4000-8000-000000000001", want: "019b6cd7-712d-460b-b0c4-7939ed7197aa"},
+		{original: "ffffffff-ffff-1fff-bfff-ffffffffffff", want: "40cfa839-2a5c-1afd-8ed5-73bbb7a63419"},
+		{original: "6ba7b810-9dad-11d1-80b4-00c04fd430c8", want: "23f70d37-c876-1a62-a5b5-3fa242d1fbed"},
+	} {
+		got, err := Encrypt(obfuscateKey, data.original)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != data.want {
+			t.Errorf("want: %v, got: %v", data.want, got)
+		}
+		if _, err := FromString(got.String()); err != nil {
+			t.Error(err)
+		}
+
+		twice, err := Encrypt(obfuscateKey, got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if twice == data.original {
+			t.Errorf("encrypting twice resulted in the original uuid: %v", twice)
+		}
+
+		back, err := Decrypt(obfuscateKey, got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back != data.original {
+			t.Errorf("want: %v, got: %v", data.original, back)
+		}
+	}
+}
+
+func TestEncryptUpper(t *testing.T) {
+	got, err := Encrypt(obfuscateKey, "AFE40693-8F63-4766-85F1-250A427F1DB5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := UUID("12128c4b-ff9e-42f1-9d06-ab3c852424b1"); got != want {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+}
+
+func TestEncryptError(t *testing.T) {
+	for _, data := range []struct {
+		name     string
+		key      []byte
+		original UUID
+	}{
+		{name: "empty key", key: nil, original: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{name: "nil", key: obfuscateKey, original: Nil},
+		{name: "zero", key: obfuscateKey, original: "00000000-0000-0000-0000-000000000000"},
+		{name: "max", key: obfuscateKey, original: Max},
+		{name: "malformed", key: obfuscateKey, original: "asda"},
+		{name: "invalid version", key: obfuscateKey, original: "99999999-9999-6999-9999-250a427f1db5"},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			if _, err := Encrypt(data.key, data.original); err == nil {
+				t.Errorf("expected error, but got nothing for %v", data.original)
+			}
+			if _, err := Decrypt(data.key, data.original); err == nil {
+				t.Errorf("expected error, but got nothing for %v", data.original)
+			}
+		})
+	}
+}
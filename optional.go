@@ -0,0 +1,24 @@
+package uuid
+
+// Ptr returns a pointer to a copy of u, eg: for optional fields.
+func (u UUID) Ptr() *UUID {
+	return &u
+}
+
+// FromPtr returns the uuid p points to, Nil if p is nil.
+func FromPtr(p *UUID) UUID {
+	if p == nil {
+		return Nil
+	}
+
+	return *p
+}
+
+// ValueOr returns the uuid p points to, or def if p is nil or points to Nil.
+func ValueOr(p *UUID, def UUID) UUID {
+	if p == nil || *p == Nil {
+		return def
+	}
+
+	return *p
+}
@@ -0,0 +1,104 @@
+package uuid
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+)
+
+func TestPtr(t *testing.T) {
+	u := UUID("afe40693-8f63-4766-85f1-250a427f1db5")
+
+	p := u.Ptr()
+	if *p != u {
+		t.Errorf("want: %v, got: %v", u, *p)
+	}
+
+	*p = Nil
+	if u != "afe40693-8f63-4766-85f1-250a427f1db5" {
+		t.Errorf("Ptr did not copy the uuid, got: %v", u)
+	}
+
+	if got := FromPtr(u.Ptr()); got != u {
+		t.Errorf("want: %v, got: %v", u, got)
+	}
+	if got := FromPtr(nil); got != Nil {
+		t.Errorf("want Nil, got: %v", got)
+	}
+}
+
+func TestValueOr(t *testing.T) {
+	u := UUID("afe40693-8f63-4766-85f1-250a427f1db5")
+	def := UUID("43ae2f25-802d-4aae-be57-b7acefe336ac")
+
+	for _, data := range []struct {
+		name string
+		p    *UUID
+		want UUID
+	}{
+		{name: "set", p: u.Ptr(), want: u},
+		{name: "nil pointer", p: nil, want: def},
+		{name: "pointer to Nil", p: Nil.Ptr(), want: def},
+	} {
+		if got := ValueOr(data.p, def); got != data.want {
+			t.Errorf("%v want: %v, got: %v", data.name, data.want, got)
+		}
+	}
+}
+
+func TestPtrJSON(t *testing.T) {
+	type entity struct {
+		ID *UUID `json:"id"`
+	}
+
+	for _, data := range []struct {
+		original entity
+		json     string
+	}{
+		{original: entity{ID: nil}, json: `{"id":null}`},
+		{original: entity{ID: UUID("afe40693-8f63-4766-85f1-250a427f1db5").Ptr()}, json: `{"id":"afe40693-8f63-4766-85f1-250a427f1db5"}`},
+	} {
+		b, err := json.Marshal(data.original)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != data.json {
+			t.Errorf("want: %s, got: %s", data.json, b)
+		}
+
+		var got entity
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Fatal(err)
+		}
+		if FromPtr(got.ID) != FromPtr(data.original.ID) || (got.ID == nil) != (data.original.ID == nil) {
+			t.Errorf("want: %v, got: %v", data.original.ID, got.ID)
+		}
+	}
+}
+
+func TestPtrSql(t *testing.T) {
+	for _, data := range []struct {
+		name string
+		p    *UUID
+		want driver.Value
+	}{
+		{name: "nil pointer", p: nil, want: nil},
+		{name: "pointer to Nil", p: Nil.Ptr(), want: nil},
+	} {
+		got, err := driver.DefaultParameterConverter.ConvertValue(data.p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != data.want {
+			t.Errorf("%v want: %v, got: %v", data.name, data.want, got)
+		}
+	}
+
+	got, err := driver.DefaultParameterConverter.ConvertValue(UUID("afe40693-8f63-4766-85f1-250a427f1db5").Ptr())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, ok := got.([]byte); !ok || len(b) != size {
+		t.Errorf("want 16 bytes, got: %v", got)
+	}
+}
@@ -0,0 +1,18 @@
+// Package otel converts OpenTelemetry trace ids into uuids.
+// It lives in its own package so the OpenTelemetry dependency is only pulled in when it is used.
+package otel
+
+import (
+	"github.com/proemergotech/uuid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// FromSpanContext returns the trace id of the span context as a uuid, see uuid.FromTraceID.
+// Returns uuid.Nil if the span context has no valid trace id.
+func FromSpanContext(sc trace.SpanContext) uuid.UUID {
+	if !sc.HasTraceID() {
+		return uuid.Nil
+	}
+
+	return uuid.FromTraceID(sc.TraceID())
+}
@@ -0,0 +1,40 @@
+package otel
+
+import (
+	"testing"
+
+	"github.com/proemergotech/uuid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestFromSpanContext(t *testing.T) {
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	if err != nil {
+		t.Fatal(err)
+	}
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, data := range []struct {
+		name string
+		sc   trace.SpanContext
+		want uuid.UUID
+	}{
+		{
+			name: "valid",
+			sc:   trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}),
+			want: "4bf92f35-77b3-4da6-a3ce-929d0e0e4736",
+		},
+		{
+			name: "empty",
+			sc:   trace.SpanContext{},
+			want: uuid.Nil,
+		},
+	} {
+		if got := FromSpanContext(data.sc); got != data.want {
+			t.Errorf("%v want: %s, got: %s", data.name, data.want, got)
+		}
+	}
+}
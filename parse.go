@@ -0,0 +1,367 @@
+package uuid
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ParseAny parses uuid in any of the common textual formats:
+// canonical, eg: afe40693-8f63-4766-85f1-250a427f1db5
+// hash, eg: afe406938f63476685f1250a427f1db5
+// braced, eg: {afe40693-8f63-4766-85f1-250a427f1db5}
+// urn, eg: urn:uuid:afe40693-8f63-4766-85f1-250a427f1db5
+// All formats are case-insensitive, the version and variant bits are validated as in FromString.
+func ParseAny(str string) (UUID, error) {
+	switch {
+	case hasURNPrefix(str):
+		return FromURN(str)
+	case strings.HasPrefix(str, "{") || strings.HasSuffix(str, "}"):
+		return FromBraced(str)
+	case len(str) == 32:
+		return FromHashLike(str)
+	default:
+		return FromString(str)
+	}
+}
+
+// LenientFromString parses uuid in canonical format like FromString, but accepts any version and variant bits,
+// eg: NCS or Microsoft variant uuids.
+// @warning - Such uuids are still rejected by FromString, so they do not survive UnmarshalJSON or Scan,
+// and MarshalJSON rejects them unless disabled with SetMarshalValidation.
+func LenientFromString(str string) (UUID, error) {
+	if str == "" || str == "00000000-0000-0000-0000-000000000000" {
+		return Nil, nil
+	}
+
+	if !isHexLayout(str) {
+		return Nil, errInvalid("invalid uuid: " + str)
+	}
+
+	return UUID(strings.ToLower(str)), nil
+}
+
+// FromStringTrimmed parses uuid in canonical format like FromString, but trims leading and trailing (unicode)
+// whitespace first, eg: for ids copied from spreadsheets. Whitespace inside the uuid is still rejected,
+// and input consisting of whitespace only results in Nil like the empty string. See TrimmedUUID for text and json.
+func FromStringTrimmed(str string) (UUID, error) {
+	return FromString(strings.TrimSpace(str))
+}
+
+// FromStringV4 parses uuid in canonical format like FromString, but only accepts version 4 uuids.
+func FromStringV4(str string) (UUID, error) {
+	return FromStringVersion(str, 4)
+}
+
+// FromStringVersion parses uuid in canonical format like FromString, but only accepts uuids with the given version.
+// Nil is accepted as in FromString. Valid uuids of another version result in a *VersionError.
+func FromStringVersion(str string, version byte) (UUID, error) {
+	u, err := FromString(str)
+	if err != nil || u == Nil {
+		return u, err
+	}
+
+	// FromString already validated that the version is a hex digit
+	got, _ := strconv.ParseUint(string(u[14]), 16, 8)
+	if byte(got) != version {
+		return Nil, &VersionError{Input: str, Expected: version, Actual: byte(got)}
+	}
+
+	return u, nil
+}
+
+// VersionError is returned by FromStringVersion for valid uuids of another version,
+// it wraps both ErrInvalidUUID and ErrWrongVersion.
+type VersionError struct {
+	Input    string
+	Expected byte
+	Actual   byte
+}
+
+func (e *VersionError) Error() string {
+	return "invalid uuid version, expected: " + strconv.Itoa(int(e.Expected)) +
+		", got: " + strconv.Itoa(int(e.Actual)) + ", uuid: " + e.Input
+}
+
+func (e *VersionError) Unwrap() []error {
+	return []error{ErrInvalidUUID, ErrWrongVersion}
+}
+
+// MustFromString is like FromString but panics if str is invalid.
+// It simplifies safe initialization of global variables holding uuids.
+func MustFromString(str string) UUID {
+	u, err := FromString(str)
+	if err != nil {
+		panic(`uuid: FromString(` + strconv.Quote(str) + `): ` + err.Error())
+	}
+
+	return u
+}
+
+// MustFromHashLike is like FromHashLike but panics if str is invalid.
+// It simplifies safe initialization of global variables holding uuids.
+func MustFromHashLike(str string) UUID {
+	u, err := FromHashLike(str)
+	if err != nil {
+		panic(`uuid: FromHashLike(` + strconv.Quote(str) + `): ` + err.Error())
+	}
+
+	return u
+}
+
+// FromStringOrNil is like FromString but returns Nil instead of an error if str is invalid.
+// @warning - Invalid input is indistinguishable from missing input, use FromString when the difference matters.
+func FromStringOrNil(str string) UUID {
+	u, err := FromString(str)
+	if err != nil {
+		return Nil
+	}
+
+	return u
+}
+
+// FromHashLikeOrNil is like FromHashLike but returns Nil instead of an error if str is invalid.
+// @warning - Invalid input is indistinguishable from missing input, use FromHashLike when the difference matters.
+func FromHashLikeOrNil(str string) UUID {
+	u, err := FromHashLike(str)
+	if err != nil {
+		return Nil
+	}
+
+	return u
+}
+
+// fromWrapped parses the canonical uuid inside a prefix or braces, the wrapper must not be empty.
+func fromWrapped(str string, inner string) (UUID, error) {
+	if inner == "" {
+		return Nil, errInvalid("invalid uuid: " + str)
+	}
+
+	return FromString(inner)
+}
+
+// ParseBytes parses uuid in canonical or hash format from a byte slice, eg: afe40693-8f63-4766-85f1-250a427f1db5
+// It behaves like FromString (and FromHashLike for 32 bytes long input), but only allocates for the result
+// and for the *ParseError of invalid input.
+func ParseBytes(b []byte) (UUID, error) {
+	var buf [36]byte
+
+	switch len(b) {
+	case 0:
+		return Nil, nil
+	case 32:
+		copy(buf[0:8], b[0:8])
+		buf[8] = '-'
+		copy(buf[9:13], b[8:12])
+		buf[13] = '-'
+		copy(buf[14:18], b[12:16])
+		buf[18] = '-'
+		copy(buf[19:23], b[16:20])
+		buf[23] = '-'
+		copy(buf[24:36], b[20:32])
+	case 36:
+		copy(buf[:], b)
+	default:
+		return Nil, checkFormat(string(b), len(b) == 32)
+	}
+
+	if isZero(buf[:]) {
+		return Nil, nil
+	}
+
+	if isMax(buf[:]) {
+		return Max, nil
+	}
+
+	if !isCanonical(buf[:]) {
+		return Nil, checkFormat(string(b), len(b) == 32)
+	}
+
+	for i, c := range buf {
+		if 'A' <= c && c <= 'F' {
+			buf[i] = c + 'a' - 'A'
+		}
+	}
+
+	return UUID(buf[:]), nil
+}
+
+// IsValid reports whether FromString would accept str, without allocations.
+func IsValid(str string) bool {
+	return str == "" || isZero(str) || isMax(str) || isCanonical(str)
+}
+
+// IsValidBytes is like IsValid for a byte slice.
+func IsValidBytes(b []byte) bool {
+	return len(b) == 0 || isZero(b) || isMax(b) || isCanonical(b)
+}
+
+// isCanonical reports whether s is a valid uuid in canonical format, the same as checkFormat but for byte slices too.
+func isCanonical[T string | []byte](s T) bool {
+	if !isHexLayout(s) {
+		return false
+	}
+
+	// version
+	if s[14] < '1' || s[14] > '5' {
+		return false
+	}
+
+	// variant
+	switch s[19] {
+	case '8', '9', 'a', 'b', 'A', 'B':
+	default:
+		return false
+	}
+
+	return true
+}
+
+// isHexLayout reports whether s consists of hex digits in 8-4-4-4-12 groups separated by dashes.
+func isHexLayout[T string | []byte](s T) bool {
+	if len(s) != 36 {
+		return false
+	}
+
+	for i := 0; i < 36; i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return false
+			}
+		default:
+			if !isHex(s[i]) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// isZero reports whether s is the zero uuid in canonical format.
+func isZero[T string | []byte](s T) bool {
+	if len(s) != 36 {
+		return false
+	}
+
+	for i := 0; i < 36; i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return false
+			}
+		default:
+			if s[i] != '0' {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// isMax reports whether s is the Max UUID in canonical format, case-insensitively.
+func isMax[T string | []byte](s T) bool {
+	if len(s) != len(Max) {
+		return false
+	}
+
+	for i := 0; i < len(Max); i++ {
+		if s[i] != Max[i] && (Max[i] != 'f' || s[i] != 'F') {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+// ParseReason is the reason of a ParseError.
+type ParseReason int
+
+const (
+	// ReasonLength means the input is too short or too long.
+	ReasonLength ParseReason = iota + 1
+	// ReasonCharacter means a character is not a hex digit, or not a dash where a dash is expected.
+	ReasonCharacter
+	// ReasonVersion means the version digit is not between 1 and 5.
+	ReasonVersion
+	// ReasonVariant means the variant digit is not 8, 9, a or b (RFC 4122 variant).
+	ReasonVariant
+)
+
+func (r ParseReason) String() string {
+	switch r {
+	case ReasonLength:
+		return "wrong length"
+	case ReasonCharacter:
+		return "invalid character"
+	case ReasonVersion:
+		return "invalid version"
+	case ReasonVariant:
+		return "invalid variant"
+	default:
+		return "unknown reason"
+	}
+}
+
+// ParseError is returned by FromString and FromHashLike for invalid input, it wraps ErrInvalidUUID.
+// Offset is the byte offset of the invalid character in Input, for ReasonLength it is the offset where
+// Input ends or the first extra character.
+type ParseError struct {
+	Input  string
+	Offset int
+	Reason ParseReason
+}
+
+func (e *ParseError) Error() string {
+	if e.Reason == ReasonLength {
+		return "invalid uuid: " + e.Input + " (" + e.Reason.String() + ": " + strconv.Itoa(len(e.Input)) + ")"
+	}
+
+	return "invalid uuid: " + e.Input + " (" + e.Reason.String() + " at offset " + strconv.Itoa(e.Offset) + ")"
+}
+
+func (e *ParseError) Unwrap() error {
+	return ErrInvalidUUID
+}
+
+// checkFormat validates str in canonical or (if hash is true) hash format, including the version and variant.
+// It returns a *ParseError for the first problem found from left to right.
+func checkFormat(str string, hash bool) error {
+	n, versionAt, variantAt := 36, 14, 19
+	if hash {
+		n, versionAt, variantAt = 32, 12, 16
+	}
+
+	if len(str) != n {
+		return &ParseError{Input: str, Offset: min(len(str), n), Reason: ReasonLength}
+	}
+
+	for i := 0; i < n; i++ {
+		c := str[i]
+
+		var reason ParseReason
+		switch {
+		case !hash && (i == 8 || i == 13 || i == 18 || i == 23):
+			if c != '-' {
+				reason = ReasonCharacter
+			}
+		case !isHex(c):
+			reason = ReasonCharacter
+		case i == versionAt && (c < '1' || c > '5'):
+			reason = ReasonVersion
+		case i == variantAt && strings.IndexByte("89abAB", c) < 0:
+			reason = ReasonVariant
+		}
+
+		if reason != 0 {
+			return &ParseError{Input: str, Offset: i, Reason: reason}
+		}
+	}
+
+	return nil
+}
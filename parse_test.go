@@ -0,0 +1,484 @@
+package uuid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseAny(t *testing.T) {
+	for _, data := range []struct {
+		original string
+		want     UUID
+	}{
+		{original: "", want: ""},
+		{original: "00000000-0000-0000-0000-000000000000", want: ""},
+		{original: "00000000000000000000000000000000", want: ""},
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{original: "AFE40693-8F63-4766-85F1-250A427F1DB5", want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{original: "afe406938f63476685f1250a427f1db5", want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{original: "AFE406938F63476685F1250A427F1DB5", want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{original: "{afe40693-8f63-4766-85f1-250a427f1db5}", want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{original: "{AFE40693-8F63-4766-85F1-250A427F1DB5}", want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{original: "urn:uuid:afe40693-8f63-4766-85f1-250a427f1db5", want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{original: "URN:UUID:AFE40693-8F63-4766-85F1-250A427F1DB5", want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+	} {
+		got, err := ParseAny(data.original)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if data.want != got {
+			t.Errorf("ParseAny(%v) want: %s, got: %s", data.original, data.want, got)
+		}
+	}
+}
+
+func TestParseAnyError(t *testing.T) {
+	for _, data := range []struct {
+		name     string
+		original string
+	}{
+		{name: "garbage", original: "asda"},
+		{name: "invalid character", original: "gfe40693-8f63-4766-85f1-250a427f1db5"},
+		{name: "invalid version bit", original: "99999999-9999-6999-9999-250a427f1db5"},
+		{name: "invalid variant bit", original: "99999999999949991999250a427f1db5"},
+		{name: "opening brace only", original: "{afe40693-8f63-4766-85f1-250a427f1db5"},
+		{name: "closing brace only", original: "afe40693-8f63-4766-85f1-250a427f1db5}"},
+		{name: "single brace", original: "{"},
+		{name: "empty braces", original: "{}"},
+		{name: "braced hash", original: "{afe406938f63476685f1250a427f1db5}"},
+		{name: "empty urn", original: "urn:uuid:"},
+		{name: "urn with trailing junk", original: "urn:uuid:afe40693-8f63-4766-85f1-250a427f1db5x"},
+		{name: "braced urn", original: "{urn:uuid:afe40693-8f63-4766-85f1-250a427f1db5}"},
+		{name: "urn with braces", original: "urn:uuid:{afe40693-8f63-4766-85f1-250a427f1db5}"},
+		{name: "whitespace", original: " afe40693-8f63-4766-85f1-250a427f1db5"},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			_, err := ParseAny(data.original)
+			if err == nil {
+				t.Errorf("expected error, but got nothing for %v", data.original)
+			}
+		})
+	}
+}
+
+func TestMustFromString(t *testing.T) {
+	for orig, exp := range tests {
+		if got := MustFromString(orig); got.String() != exp {
+			t.Errorf("expected: %s, got: %s", exp, got)
+		}
+	}
+
+	if got := MustFromHashLike("afe406938f63476685f1250a427f1db5"); got != "afe40693-8f63-4766-85f1-250a427f1db5" {
+		t.Errorf("expected: %s, got: %s", "afe40693-8f63-4766-85f1-250a427f1db5", got)
+	}
+}
+
+func TestMustFromStringPanic(t *testing.T) {
+	for name, fn := range map[string]func(string) UUID{
+		"FromString":   MustFromString,
+		"FromHashLike": MustFromHashLike,
+	} {
+		t.Run(name, func(t *testing.T) {
+			const invalid = "99999999-9999-6999-9999-250a427f1db5"
+
+			defer func() {
+				r := recover()
+				if r == nil {
+					t.Fatal("expected panic, but got nothing")
+				}
+
+				msg, ok := r.(string)
+				if !ok || !strings.Contains(msg, name) || !strings.Contains(msg, invalid) {
+					t.Errorf("panic message should contain the function name and the input, got: %v", r)
+				}
+			}()
+
+			fn(invalid)
+		})
+	}
+}
+
+func TestFromStringOrNil(t *testing.T) {
+	for _, data := range []struct {
+		original string
+		want     UUID
+	}{
+		{original: "", want: ""},
+		{original: "00000000-0000-0000-0000-000000000000", want: ""},
+		{original: "AFE40693-8F63-4766-85F1-250a427F1DB5", want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{original: "99999999-9999-6999-9999-250a427f1db5", want: ""},
+		{original: "99999999-9999-4999-1999-250a427f1db5", want: ""},
+		{original: "afe406938f63476685f1250a427f1db5", want: ""},
+		{original: "asda", want: ""},
+	} {
+		if got := FromStringOrNil(data.original); data.want != got {
+			t.Errorf("FromStringOrNil(%v) want: %s, got: %s", data.original, data.want, got)
+		}
+	}
+}
+
+func TestFromHashLikeOrNil(t *testing.T) {
+	for _, data := range []struct {
+		original string
+		want     UUID
+	}{
+		{original: "", want: ""},
+		{original: "00000000000000000000000000000000", want: ""},
+		{original: "AFE406938F63476685F1250a427F1DB5", want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{original: "99999999999969999999250a427f1db5", want: ""},
+		{original: "99999999999949991999250a427f1db5", want: ""},
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", want: ""},
+		{original: "asda", want: ""},
+	} {
+		if got := FromHashLikeOrNil(data.original); data.want != got {
+			t.Errorf("FromHashLikeOrNil(%v) want: %s, got: %s", data.original, data.want, got)
+		}
+	}
+}
+
+func TestParseBytes(t *testing.T) {
+	for orig, exp := range tests {
+		got, err := ParseBytes([]byte(orig))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got.String() != exp {
+			t.Errorf("expected: %s, got: %s", exp, got)
+		}
+	}
+
+	for _, data := range []struct {
+		original string
+		want     UUID
+	}{
+		{original: "00000000000000000000000000000000", want: ""},
+		{original: "AFE406938F63476685F1250a427F1DB5", want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+	} {
+		got, err := ParseBytes([]byte(data.original))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if data.want != got {
+			t.Errorf("want: %s, got: %s", data.want, got)
+		}
+	}
+}
+
+func TestParseBytesError(t *testing.T) {
+	// skip the hash form, ParseBytes accepts it
+	for _, orig := range append(testErrors[:2:2], testErrors[3:]...) {
+		_, err := ParseBytes([]byte(orig))
+		if err == nil {
+			t.Errorf("expected error, but got nothing for %v", orig)
+		}
+	}
+
+	for _, orig := range []string{
+		"99999999999969999999250a427f1db5",
+		"99999999999949991999250a427f1db5",
+		"afe40693-8f63-4766-85f1-250a427f1db",
+		"afe40693-8f63-4766-85f1-250a427f1db5a",
+		"afe40693x8f63-4766-85f1-250a427f1db5",
+		"afe40693-8f63-4766-85f1-250a427f1dbg",
+		"afe40693-8f63-4766-85f1-250a-27f1db5",
+		"0000000000000000000000000000000-",
+	} {
+		_, err := ParseBytes([]byte(orig))
+		if err == nil {
+			t.Errorf("expected error, but got nothing for %v", orig)
+		}
+
+		// must agree with FromString and FromHashLike
+		parse := FromString
+		if len(orig) == 32 {
+			parse = FromHashLike
+		}
+		_, want := parse(orig)
+
+		var got, wantErr *ParseError
+		if !errors.As(err, &got) || !errors.As(want, &wantErr) {
+			t.Errorf("want *ParseError, got: %v, %v for %v", err, want, orig)
+			continue
+		}
+		if *got != *wantErr {
+			t.Errorf("want: %v, got: %v", wantErr, got)
+		}
+	}
+}
+
+func BenchmarkFromString(b *testing.B) {
+	input := []byte("AFE40693-8F63-4766-85F1-250A427F1DB5")
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = FromString(string(input))
+	}
+}
+
+func BenchmarkParseBytes(b *testing.B) {
+	input := []byte("AFE40693-8F63-4766-85F1-250A427F1DB5")
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = ParseBytes(input)
+	}
+}
+
+func TestIsValid(t *testing.T) {
+	for orig := range tests {
+		if !IsValid(orig) {
+			t.Errorf("IsValid(%q) want: true, got: false", orig)
+		}
+		if !IsValidBytes([]byte(orig)) {
+			t.Errorf("IsValidBytes(%q) want: true, got: false", orig)
+		}
+	}
+
+	for _, orig := range testErrors {
+		if IsValid(orig) {
+			t.Errorf("IsValid(%q) want: false, got: true", orig)
+		}
+		if IsValidBytes([]byte(orig)) {
+			t.Errorf("IsValidBytes(%q) want: false, got: true", orig)
+		}
+	}
+
+	for _, orig := range []string{"FFFFFFFF-FFFF-FFFF-FFFF-FFFFFFFFFFFF", "ffffffff-ffff-ffff-ffff-fffffffffffe", "fffffffffffffffffffffffffffffffffff-"} {
+		_, err := FromString(orig)
+		if IsValid(orig) != (err == nil) {
+			t.Errorf("IsValid(%q) does not agree with FromString, got error: %v", orig, err)
+		}
+	}
+}
+
+func TestIsValidAllocs(t *testing.T) {
+	str := "AFE40693-8F63-4766-85F1-250A427F1DB5"
+	b := []byte(str)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		IsValid(str)
+		IsValidBytes(b)
+		IsValid(string(Max))
+		IsValid("asda")
+	})
+	if allocs != 0 {
+		t.Errorf("want: 0 allocations, got: %v", allocs)
+	}
+}
+
+func BenchmarkIsValid(b *testing.B) {
+	input := "AFE40693-8F63-4766-85F1-250A427F1DB5"
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = IsValid(input)
+	}
+}
+
+func BenchmarkIsValidBytes(b *testing.B) {
+	input := []byte("AFE40693-8F63-4766-85F1-250A427F1DB5")
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = IsValidBytes(input)
+	}
+}
+
+func TestLenientFromString(t *testing.T) {
+	for orig, exp := range tests {
+		got, err := LenientFromString(orig)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got.String() != exp {
+			t.Errorf("expected: %s, got: %s", exp, got)
+		}
+	}
+
+	for _, data := range []struct {
+		name     string
+		original string
+		want     UUID
+		wantHash string
+	}{
+		{
+			name:     "ncs variant",
+			original: "99999999-9999-4999-1999-250a427f1db5",
+			want:     "99999999-9999-4999-1999-250a427f1db5",
+			wantHash: "99999999999949991999250a427f1db5",
+		},
+		{
+			name:     "microsoft variant",
+			original: "AFE40693-8F63-4766-C5F1-250A427F1DB5",
+			want:     "afe40693-8f63-4766-c5f1-250a427f1db5",
+			wantHash: "afe406938f634766c5f1250a427f1db5",
+		},
+		{
+			name:     "unknown version",
+			original: "99999999-9999-f999-9999-250a427f1db5",
+			want:     "99999999-9999-f999-9999-250a427f1db5",
+			wantHash: "999999999999f9999999250a427f1db5",
+		},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			got, err := LenientFromString(data.original)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if data.want != got {
+				t.Errorf("want: %s, got: %s", data.want, got)
+			}
+			if got.HashLike() != data.wantHash {
+				t.Errorf("want: %s, got: %s", data.wantHash, got.HashLike())
+			}
+
+			if _, err := FromString(data.original); err == nil {
+				t.Errorf("FromString accepts %v", data.original)
+			}
+		})
+	}
+}
+
+func TestLenientFromStringError(t *testing.T) {
+	for _, orig := range []string{
+		"asda",
+		"gfe40693-8f63-4766-85f1-250a427f1db5",
+		"afe406938f63476685f1250a427f1db5",
+		"afe40693-8f63-4766-85f1-250a427f1db",
+		"afe40693-8f63-4766-85f1-250a427f1db5a",
+		"afe40693-8f634-766-85f1-250a427f1db5",
+	} {
+		_, err := LenientFromString(orig)
+		if err == nil {
+			t.Errorf("expected error, but got nothing for %v", orig)
+		}
+	}
+}
+
+func TestFromStringVersion(t *testing.T) {
+	for _, data := range []struct {
+		original string
+		version  byte
+		want     UUID
+	}{
+		{original: "", version: 4, want: ""},
+		{original: "00000000-0000-0000-0000-000000000000", version: 4, want: ""},
+		{original: "AFE40693-8F63-4766-85F1-250A427F1DB5", version: 4, want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{original: "afe40693-8f63-1766-85f1-250a427f1db5", version: 1, want: "afe40693-8f63-1766-85f1-250a427f1db5"},
+		{original: "afe40693-8f63-5766-85f1-250a427f1db5", version: 5, want: "afe40693-8f63-5766-85f1-250a427f1db5"},
+	} {
+		got, err := FromStringVersion(data.original, data.version)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if data.want != got {
+			t.Errorf("want: %s, got: %s", data.want, got)
+		}
+	}
+
+	got, err := FromStringV4("afe40693-8f63-4766-85f1-250a427f1db5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "afe40693-8f63-4766-85f1-250a427f1db5" {
+		t.Errorf("want: %s, got: %s", "afe40693-8f63-4766-85f1-250a427f1db5", got)
+	}
+}
+
+func TestFromStringVersionError(t *testing.T) {
+	for _, data := range []struct {
+		original string
+		version  byte
+		wantErr  string
+	}{
+		{original: "afe40693-8f63-1766-85f1-250a427f1db5", version: 4, wantErr: "expected: 4, got: 1"},
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", version: 1, wantErr: "expected: 1, got: 4"},
+		{original: "asda", version: 4, wantErr: "invalid uuid: asda"},
+	} {
+		_, err := FromStringVersion(data.original, data.version)
+		if err == nil {
+			t.Fatalf("expected error, but got nothing for %v", data.original)
+		}
+
+		if !strings.Contains(err.Error(), data.wantErr) {
+			t.Errorf("expected error to contain %v, got: %v", data.wantErr, err)
+		}
+	}
+
+	_, err := FromStringV4("afe40693-8f63-3766-85f1-250a427f1db5")
+	var versionErr *VersionError
+	if !errors.As(err, &versionErr) {
+		t.Fatalf("want *VersionError, got: %v", err)
+	}
+	if versionErr.Expected != 4 || versionErr.Actual != 3 {
+		t.Errorf("want: 4, 3, got: %v, %v", versionErr.Expected, versionErr.Actual)
+	}
+	if !errors.Is(err, ErrInvalidUUID) || !errors.Is(err, ErrWrongVersion) {
+		t.Errorf("want: %v and %v, got: %v", ErrInvalidUUID, ErrWrongVersion, err)
+	}
+
+	// malformed input is not a version error
+	_, err = FromStringV4("asda")
+	if errors.As(err, &versionErr) || errors.Is(err, ErrWrongVersion) {
+		t.Errorf("unexpected version error: %v", err)
+	}
+}
+
+var trimmedTests = []string{
+	"afe40693-8f63-4766-85f1-250a427f1db5\n",
+	"\tAFE40693-8F63-4766-85F1-250A427F1DB5",
+	"  afe40693-8f63-4766-85f1-250a427f1db5\r\n",
+	"\u00a0afe40693-8f63-4766-85f1-250a427f1db5\u00a0",
+}
+
+func TestFromStringTrimmed(t *testing.T) {
+	want := UUID("afe40693-8f63-4766-85f1-250a427f1db5")
+
+	for _, orig := range trimmedTests {
+		got, err := FromStringTrimmed(orig)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("want: %v, got: %v", want, got)
+		}
+
+		// the other parsers are strict
+		if _, err := FromString(orig); err == nil {
+			t.Errorf("expected error, but got nothing for %q", orig)
+		}
+		if IsValid(orig) {
+			t.Errorf("want: false, got: true for %q", orig)
+		}
+		if _, err := ParseList(orig+","+string(want), ","); err == nil {
+			t.Errorf("expected error, but got nothing for %q", orig)
+		}
+	}
+
+	// whitespace only is the same as the empty string
+	for _, orig := range []string{" ", "\n", "\t \u00a0"} {
+		if got, err := FromStringTrimmed(orig); err != nil || got != Nil {
+			t.Errorf("want: %v, got: %v, %v for %q", Nil, got, err, orig)
+		}
+	}
+}
+
+func TestFromStringTrimmedError(t *testing.T) {
+	for _, orig := range append([]string{
+		"afe40693-8f63-4766- 85f1-250a427f1db5",
+		"afe40693-8f63-4766-85f1 -250a427f1db5",
+		"afe40693-8f63-4766-85f1-250a427f1db5 x",
+		"{ afe40693-8f63-4766-85f1-250a427f1db5 }",
+	}, testErrors...) {
+		if _, err := FromStringTrimmed(orig); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("want: %v, got: %v for %q", ErrInvalidUUID, err, orig)
+		}
+	}
+}
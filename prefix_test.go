@@ -0,0 +1,58 @@
+package uuid
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestNewV4WithPrefix(t *testing.T) {
+	for _, prefix := range [][]byte{
+		nil,
+		{0x01},
+		{0xde, 0xad},
+		{0xde, 0xad, 0xbe, 0xef},
+	} {
+		u, err := NewV4WithPrefix(prefix)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := FromString(u.String()); err != nil {
+			t.Error(err)
+		}
+
+		got := u.Prefix(len(prefix))
+		if !bytes.Equal(prefix, got) {
+			t.Errorf("want: %x, got: %x", prefix, got)
+		}
+	}
+}
+
+func TestNewV4WithPrefixError(t *testing.T) {
+	_, err := NewV4WithPrefix([]byte{1, 2, 3, 4, 5})
+	if err == nil {
+		t.Error("expected error, but got nothing for 5 bytes prefix")
+	}
+}
+
+func TestPrefix(t *testing.T) {
+	u := UUID("afe40693-8f63-4766-85f1-250a427f1db5")
+	for _, data := range []struct {
+		uuid UUID
+		n    int
+		want []byte
+	}{
+		{uuid: u, n: 0, want: []byte{}},
+		{uuid: u, n: 2, want: []byte{0xaf, 0xe4}},
+		{uuid: u, n: 4, want: []byte{0xaf, 0xe4, 0x06, 0x93}},
+		{uuid: u, n: 5, want: nil},
+		{uuid: u, n: -1, want: nil},
+		{uuid: Nil, n: 2, want: nil},
+		{uuid: UUID("asda"), n: 2, want: nil},
+	} {
+		got := data.uuid.Prefix(data.n)
+		if !bytes.Equal(data.want, got) || (data.want == nil) != (got == nil) {
+			t.Errorf("%v.Prefix(%v) want: %x, got: %x", data.uuid, data.n, data.want, got)
+		}
+	}
+}
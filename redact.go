@@ -0,0 +1,62 @@
+package uuid
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	defaultRedactedVisible = 8
+	redactedMask           = "•"
+	redactedNil            = "<nil-uuid>"
+	redactedInvalid        = "<invalid-uuid>"
+)
+
+var redactedVisible atomic.Int32
+
+func init() {
+	redactedVisible.Store(defaultRedactedVisible)
+}
+
+// SetRedactedVisible sets how many hex digits Redacted leaves visible, the default is 8.
+// n is clamped to the [0, 32] range. It is safe to call concurrently with Redacted.
+func SetRedactedVisible(n int) {
+	redactedVisible.Store(int32(min(max(n, 0), 32)))
+}
+
+// Redacted returns the uuid for logs with only the first hex digits visible (see SetRedactedVisible)
+// and the rest masked, eg: afe40693-••••-••••-••••-••••••••••••
+// Returns <nil-uuid> for Nil and <invalid-uuid> for malformed uuids, without revealing any of their characters.
+func (u UUID) Redacted() string {
+	if u == Nil {
+		return redactedNil
+	}
+
+	if !isHexLayout(string(u)) {
+		return redactedInvalid
+	}
+
+	visible := int(redactedVisible.Load())
+
+	var sb strings.Builder
+	sb.Grow(4 + visible + (32-visible)*len(redactedMask))
+
+	digits := 0
+	for i := 0; i < len(u); i++ {
+		c := u[i]
+		switch {
+		case c == '-':
+			sb.WriteByte('-')
+		case digits < visible:
+			if 'A' <= c && c <= 'F' {
+				c += 'a' - 'A'
+			}
+			sb.WriteByte(c)
+			digits++
+		default:
+			sb.WriteString(redactedMask)
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,41 @@
+package uuid
+
+import (
+	"testing"
+)
+
+func TestRedacted(t *testing.T) {
+	defer SetRedactedVisible(defaultRedactedVisible)
+
+	for _, data := range []struct {
+		visible  int
+		original UUID
+		want     string
+	}{
+		{visible: 8, original: "afe40693-8f63-4766-85f1-250a427f1db5", want: "afe40693-••••-••••-••••-••••••••••••"},
+		{visible: 8, original: "AFE40693-8F63-4766-85F1-250A427F1DB5", want: "afe40693-••••-••••-••••-••••••••••••"},
+		{visible: 8, original: Nil, want: "<nil-uuid>"},
+		{visible: 8, original: "afe40693", want: "<invalid-uuid>"},
+		{visible: 8, original: "afe40693-8f63-4766-85f1-250a427f1dbx", want: "<invalid-uuid>"},
+		{visible: 0, original: "afe40693-8f63-4766-85f1-250a427f1db5", want: "••••••••-••••-••••-••••-••••••••••••"},
+		{visible: 12, original: "afe40693-8f63-4766-85f1-250a427f1db5", want: "afe40693-8f63-••••-••••-••••••••••••"},
+		{visible: 32, original: "afe40693-8f63-4766-85f1-250a427f1db5", want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{visible: -1, original: "afe40693-8f63-4766-85f1-250a427f1db5", want: "••••••••-••••-••••-••••-••••••••••••"},
+		{visible: 100, original: "afe40693-8f63-4766-85f1-250a427f1db5", want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+	} {
+		SetRedactedVisible(data.visible)
+
+		if got := data.original.Redacted(); got != data.want {
+			t.Errorf("Redacted(%v) with %v visible want: %s, got: %s", data.original, data.visible, data.want, got)
+		}
+	}
+}
+
+func BenchmarkRedacted(b *testing.B) {
+	u := UUID("afe40693-8f63-4766-85f1-250a427f1db5")
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = u.Redacted()
+	}
+}
@@ -0,0 +1,82 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// MaxSequenceCounter is the largest counter a SequenceGenerator can embed in a uuid.
+const MaxSequenceCounter = 1<<57 - 1
+
+// SequenceGenerator generates strictly increasing uuids without coordination, by embedding
+// a node id and a counter next to the timestamp.
+//
+// Layout (big-endian): 48 bits unix timestamp in milliseconds (same as NewTime), 4 version bits,
+// 12 high bits of the counter, 2 variant bits, the time marker bit (see IsTimeUUID), 45 low bits of the counter,
+// 16 bits node id.
+// Uuids of different generators are unique as long as their node ids differ.
+type SequenceGenerator struct {
+	mu      sync.Mutex
+	node    uint16
+	counter uint64
+	ms      uint64
+	now     func() time.Time
+}
+
+// NewSequenceGenerator creates a SequenceGenerator for the given node, the first uuid generated will contain counter.
+// To continue after a restart, pass the value of a previous generator's Counter().
+func NewSequenceGenerator(node uint16, counter uint64) (*SequenceGenerator, error) {
+	if counter > MaxSequenceCounter {
+		return nil, errors.New("uuid sequence counter too big: " + strconv.FormatUint(counter, 10))
+	}
+
+	return &SequenceGenerator{node: node, counter: counter, now: time.Now}, nil
+}
+
+// Next generates the next uuid of the sequence, each uuid is greater than the previous one in both byte and string order.
+// It returns an error once the counter space is exhausted and if the clock is out of range, see TimeInRange.
+func (g *SequenceGenerator) Next() (UUID, error) {
+	g.mu.Lock()
+
+	if g.counter > MaxSequenceCounter {
+		g.mu.Unlock()
+		return Nil, errors.New("uuid sequence counter exhausted")
+	}
+
+	now := g.now()
+	if !TimeInRange(now) {
+		g.mu.Unlock()
+		return Nil, errors.New("time out of range for sequence uuids: " + now.Format(time.RFC3339Nano))
+	}
+
+	// never go back in time, the counter keeps the order within the same millisecond
+	ms := Timestamp(now)
+	if ms < g.ms {
+		ms = g.ms
+	}
+	g.ms = ms
+
+	counter := g.counter
+	g.counter++
+
+	g.mu.Unlock()
+
+	u := [size]byte{}
+	binary.BigEndian.PutUint64(u[0:8], ms<<16|counter>>45)
+	binary.BigEndian.PutUint64(u[8:16], (counter&(1<<45-1))<<16|uint64(g.node))
+	setVersion(u[:])
+	u[8] |= timeMarker
+
+	return generated(UUID(string(encodeBytes(u[:]))), KindSequence), nil
+}
+
+// Counter returns the counter the next generated uuid will contain, it can be used as a checkpoint.
+func (g *SequenceGenerator) Counter() uint64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.counter
+}
@@ -0,0 +1,152 @@
+package uuid
+
+import (
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSequenceGenerator(t *testing.T) {
+	g, err := NewSequenceGenerator(0xbeef, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now().Add(-time.Millisecond)
+
+	prev := Nil
+	for i := 0; i < 10000; i++ {
+		u, err := g.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := FromString(u.String()); err != nil {
+			t.Fatal(err)
+		}
+
+		if u.String() <= prev.String() {
+			t.Fatalf("uuid is not greater than the previous one, %v <= %v", u, prev)
+		}
+		prev = u
+
+		if node := u.HashLike()[28:]; node != "beef" {
+			t.Fatalf("invalid node in uuid: %v", u)
+		}
+	}
+
+	if !prev.IsTimeUUID() {
+		t.Errorf("expected time uuid: %v", prev)
+	}
+	tm, err := prev.TimeUUIDToTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tm.Before(start) || tm.After(time.Now()) {
+		t.Errorf("invalid time in uuid: %v", tm)
+	}
+
+	if got := g.Counter(); got != 10010 {
+		t.Errorf("want: %v, got: %v", 10010, got)
+	}
+}
+
+func TestSequenceGeneratorConcurrent(t *testing.T) {
+	g, err := NewSequenceGenerator(1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const goroutines, count = 8, 1000
+
+	var mu sync.Mutex
+	uuids := make(map[UUID]struct{}, goroutines*count)
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < count; j++ {
+				u, err := g.Next()
+				if err != nil {
+					t.Error(err)
+					return
+				}
+
+				mu.Lock()
+				if _, ok := uuids[u]; ok {
+					t.Errorf("duplicate uuid: %v", u)
+				}
+				uuids[u] = struct{}{}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := g.Counter(); got != goroutines*count {
+		t.Errorf("want: %v, got: %v", goroutines*count, got)
+	}
+}
+
+func TestSequenceGeneratorOrderAcrossCounterBits(t *testing.T) {
+	// the counter is split around the variant bits, the order must hold when the low part overflows
+	g, err := NewSequenceGenerator(0, 1<<45-2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uuids := make([]string, 0, 4)
+	for i := 0; i < 4; i++ {
+		u, err := g.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		uuids = append(uuids, u.String())
+	}
+
+	if !sort.StringsAreSorted(uuids) {
+		t.Errorf("uuids are not sorted: %v", uuids)
+	}
+}
+
+func TestSequenceGeneratorExhausted(t *testing.T) {
+	g, err := NewSequenceGenerator(0, MaxSequenceCounter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := g.Next(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := g.Next(); err == nil {
+		t.Error("expected error, but got nothing")
+	}
+
+	if _, err := NewSequenceGenerator(0, MaxSequenceCounter+1); err == nil {
+		t.Error("expected error, but got nothing")
+	}
+}
+
+func TestSequenceGeneratorTimeOutOfRange(t *testing.T) {
+	for _, tm := range []time.Time{
+		time.Unix(0, 0).Add(-time.Millisecond),
+		MaxTime().Add(time.Millisecond),
+	} {
+		g, err := NewSequenceGenerator(0, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		g.now = func() time.Time { return tm }
+
+		if _, err := g.Next(); err == nil {
+			t.Errorf("expected error, but got nothing for %v", tm)
+		}
+		if got := g.Counter(); got != 0 {
+			t.Errorf("want: 0, got: %v", got)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package uuid
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+)
+
+// Shard assigns the uuid to one of n buckets using jump consistent hashing over its 128 bits value
+// (the two 64 bits halves xor-ed together). The assignment is uniform and stable: when n grows to n+1,
+// only 1/(n+1) of the uuids move, all of them to the new bucket. Nil is always assigned to bucket 0.
+// Returns an error if n <= 0 and for malformed uuids.
+func (u UUID) Shard(n int) (int, error) {
+	if n <= 0 {
+		return 0, errors.New("invalid number of shards: " + strconv.Itoa(n))
+	}
+
+	if u == Nil {
+		return 0, nil
+	}
+
+	b, err := u.decode()
+	if err != nil {
+		return 0, errInvalid("invalid uuid: " + u.String())
+	}
+
+	v := uint128FromBytes(b)
+
+	return jumpHash(v.Hi^v.Lo, n), nil
+}
+
+// jumpHash is the jump consistent hash algorithm by Lamping and Veach, see https://arxiv.org/abs/1406.2294
+func jumpHash(key uint64, n int) int {
+	b, j := int64(-1), int64(0)
+	for j < int64(n) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+
+	return int(b)
+}
+
+// zeroUUID is the all-zero uuid, used instead of Nil where an explicit value is needed, eg: as a range boundary.
+const zeroUUID UUID = "00000000-0000-0000-0000-000000000000"
+
+// Range is a contiguous range of uuids ordered by their 128 bits value (see Compare), both ends are inclusive.
+type Range struct {
+	First UUID
+	Last  UUID
+}
+
+// Contains reports whether u is in the range.
+func (r Range) Contains(u UUID) bool {
+	return Compare(r.First, u) <= 0 && Compare(u, r.Last) <= 0
+}
+
+// SplitRange splits the 128 bits keyspace into n contiguous ranges of near-equal size, eg: for parallel scans
+// of a table ordered by uuid. The ranges are in order, without gaps or overlaps: the first starts with the
+// all-zero uuid (not Nil, so it can be used as a query parameter), the last ends with Max.
+// The boundaries are raw 128 bits values in lowercase canonical format, their version and variant bits are
+// not set, so they are accepted by LenientFromString but usually not by FromString, and Value rejects them:
+// pass them to database/sql as Bytes or String instead.
+// Returns an error if n <= 0.
+func SplitRange(n int) ([]Range, error) {
+	if n <= 0 {
+		return nil, errors.New("invalid number of ranges: " + strconv.Itoa(n))
+	}
+
+	space := new(big.Int).Lsh(big.NewInt(1), 128)
+	count := big.NewInt(int64(n))
+
+	ranges := make([]Range, n)
+	ranges[0].First = zeroUUID
+	ranges[n-1].Last = Max
+
+	start := new(big.Int)
+	var buf [size]byte
+	for i := 1; i < n; i++ {
+		// start of the i-th range: i * 2^128 / n
+		start.Mul(space, big.NewInt(int64(i)))
+		start.Quo(start, count)
+		start.FillBytes(buf[:])
+		ranges[i].First = FromUint128(uint128FromBytes(buf), false)
+
+		ranges[i-1].Last = FromUint128(uint128FromBytes(buf).Sub(Uint128{Lo: 1}), false)
+	}
+
+	return ranges, nil
+}
@@ -0,0 +1,183 @@
+package uuid
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestShard(t *testing.T) {
+	for _, data := range []struct {
+		original UUID
+		n        int
+		want     int
+	}{
+		{original: Nil, n: 1, want: 0},
+		{original: Nil, n: 1000, want: 0},
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", n: 1, want: 0},
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", n: 10, want: 1},
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", n: 100, want: 97},
+		{original: "AFE40693-8F63-4766-85F1-250A427F1DB5", n: 1000, want: 611},
+		{original: "43ae2f25-802d-4aae-be57-b7acefe336ac", n: 10, want: 8},
+		{original: "43ae2f25-802d-4aae-be57-b7acefe336ac", n: 100, want: 35},
+		{original: "43ae2f25-802d-4aae-be57-b7acefe336ac", n: 1000, want: 367},
+	} {
+		got, err := data.original.Shard(data.n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != data.want {
+			t.Errorf("Shard(%v) of %v want: %v, got: %v", data.n, data.original, data.want, got)
+		}
+	}
+
+	for _, data := range []struct {
+		name     string
+		original UUID
+		n        int
+	}{
+		{name: "zero shards", original: "afe40693-8f63-4766-85f1-250a427f1db5", n: 0},
+		{name: "negative shards", original: "afe40693-8f63-4766-85f1-250a427f1db5", n: -1},
+		{name: "malformed", original: "asda", n: 10},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			if _, err := data.original.Shard(data.n); err == nil {
+				t.Errorf("expected error, but got nothing for %v", data.original)
+			}
+		})
+	}
+}
+
+func TestShardDistribution(t *testing.T) {
+	const (
+		samples = 100000
+		n       = 10
+	)
+
+	counts := make([]int, n+1)
+	moved := 0
+	for i := 0; i < samples; i++ {
+		u := NewV4()
+
+		before, err := u.Shard(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts[before]++
+
+		after, err := u.Shard(n + 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if after != before {
+			if after != n {
+				t.Fatalf("%v moved from %v to %v instead of the new shard", u, before, after)
+			}
+			moved++
+		}
+	}
+
+	// every bucket within 5% of the expected count, the chance to fail is negligible
+	for i, count := range counts[:n] {
+		if count < samples/n*95/100 || count > samples/n*105/100 {
+			t.Errorf("shard %v has %v uuids, expected about %v", i, count, samples/n)
+		}
+	}
+
+	if want := samples / (n + 1); moved < want*90/100 || moved > want*110/100 {
+		t.Errorf("%v uuids moved when adding a shard, expected about %v", moved, want)
+	}
+}
+
+func TestSplitRange(t *testing.T) {
+	ranges, err := SplitRange(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []Range{{First: "00000000-0000-0000-0000-000000000000", Last: Max}}; !slices.Equal(ranges, want) {
+		t.Errorf("want: %v, got: %v", want, ranges)
+	}
+
+	ranges, err = SplitRange(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Range{
+		{First: "00000000-0000-0000-0000-000000000000", Last: "3fffffff-ffff-ffff-ffff-ffffffffffff"},
+		{First: "40000000-0000-0000-0000-000000000000", Last: "7fffffff-ffff-ffff-ffff-ffffffffffff"},
+		{First: "80000000-0000-0000-0000-000000000000", Last: "bfffffff-ffff-ffff-ffff-ffffffffffff"},
+		{First: "c0000000-0000-0000-0000-000000000000", Last: Max},
+	}
+	if !slices.Equal(ranges, want) {
+		t.Errorf("want: %v, got: %v", want, ranges)
+	}
+
+	ranges, err = SplitRange(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []Range{
+		{First: "00000000-0000-0000-0000-000000000000", Last: "55555555-5555-5555-5555-555555555554"},
+		{First: "55555555-5555-5555-5555-555555555555", Last: "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaa9"},
+		{First: "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa", Last: Max},
+	}
+	if !slices.Equal(ranges, want) {
+		t.Errorf("want: %v, got: %v", want, ranges)
+	}
+}
+
+func TestSplitRangeCoverage(t *testing.T) {
+	for _, n := range []int{2, 7, 16, 1000} {
+		ranges, err := SplitRange(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ranges) != n {
+			t.Fatalf("want %v ranges, got: %v", n, len(ranges))
+		}
+
+		for i, r := range ranges {
+			if _, err := LenientFromString(r.First.String()); err != nil {
+				t.Error(err)
+			}
+			if Compare(r.First, r.Last) >= 0 {
+				t.Errorf("range %v is empty: %v", i, r)
+			}
+			if i == 0 {
+				continue
+			}
+
+			// the next range starts right after the previous one
+			last, err := ranges[i-1].Last.Uint128()
+			if err != nil {
+				t.Fatal(err)
+			}
+			first, err := r.First.Uint128()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if last.Add(Uint128{Lo: 1}) != first {
+				t.Errorf("gap or overlap between %v and %v", ranges[i-1], r)
+			}
+		}
+
+		for i := 0; i < 1000; i++ {
+			u := NewV4()
+
+			found := 0
+			for _, r := range ranges {
+				if r.Contains(u) {
+					found++
+				}
+			}
+			if found != 1 {
+				t.Fatalf("%v is in %v ranges", u, found)
+			}
+		}
+	}
+
+	for _, n := range []int{0, -1} {
+		if _, err := SplitRange(n); err == nil {
+			t.Errorf("expected error, but got nothing for %v", n)
+		}
+	}
+}
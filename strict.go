@@ -0,0 +1,67 @@
+package uuid
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+)
+
+// FromStringStrict parses uuid in canonical format like FromString, but rejects the empty string
+// and the zero uuid instead of returning Nil, eg: for validating required ids.
+func FromStringStrict(str string) (UUID, error) {
+	u, err := FromString(str)
+	if err != nil {
+		return Nil, err
+	}
+
+	if u == Nil {
+		return Nil, errors.New("missing uuid: " + strconv.Quote(str))
+	}
+
+	return u, nil
+}
+
+// StrictUUID is a UUID which can not be Nil when unmarshalled, use it for required fields.
+// Unmarshalling fails for null, the empty string and the zero uuid.
+// @warning - Unmarshal methods are not called for missing json fields, those must be checked separately.
+type StrictUUID UUID
+
+func (u StrictUUID) UUID() UUID {
+	return UUID(u)
+}
+
+func (u StrictUUID) String() string {
+	return string(u)
+}
+
+func (u StrictUUID) MarshalText() ([]byte, error) {
+	return UUID(u).MarshalText()
+}
+
+func (u *StrictUUID) UnmarshalText(text []byte) error {
+	uid, err := FromStringStrict(string(text))
+	if err != nil {
+		return err
+	}
+
+	*u = StrictUUID(uid)
+
+	return nil
+}
+
+func (u StrictUUID) MarshalJSON() ([]byte, error) {
+	return UUID(u).MarshalJSON()
+}
+
+func (u *StrictUUID) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		return errors.New("missing uuid: null")
+	}
+
+	str, err := strconv.Unquote(string(b))
+	if err != nil {
+		return errInvalid("invalid json value for uuid (must be string): " + string(b))
+	}
+
+	return u.UnmarshalText([]byte(str))
+}
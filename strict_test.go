@@ -0,0 +1,68 @@
+package uuid
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFromStringStrict(t *testing.T) {
+	got, err := FromStringStrict("AFE40693-8F63-4766-85F1-250a427F1DB5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := UUID("afe40693-8f63-4766-85f1-250a427f1db5"); want != got {
+		t.Errorf("want: %s, got: %s", want, got)
+	}
+}
+
+func TestFromStringStrictError(t *testing.T) {
+	for _, orig := range append([]string{"", "00000000-0000-0000-0000-000000000000"}, testErrors...) {
+		_, err := FromStringStrict(orig)
+		if err == nil {
+			t.Errorf("expected error, but got nothing for %v", orig)
+		}
+	}
+}
+
+func TestStrictUUIDJSON(t *testing.T) {
+	var req struct {
+		ID StrictUUID `json:"id"`
+	}
+
+	err := json.Unmarshal([]byte(`{"id":"AFE40693-8F63-4766-85F1-250a427F1DB5"}`), &req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := UUID("afe40693-8f63-4766-85f1-250a427f1db5"); want != req.ID.UUID() {
+		t.Errorf("want: %s, got: %s", want, req.ID)
+	}
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"id":"afe40693-8f63-4766-85f1-250a427f1db5"}`; want != string(b) {
+		t.Errorf("want: %s, got: %s", want, b)
+	}
+}
+
+func TestStrictUUIDJSONError(t *testing.T) {
+	for _, data := range []string{
+		`{"id":null}`,
+		`{"id":""}`,
+		`{"id":"00000000-0000-0000-0000-000000000000"}`,
+		`{"id":"asda"}`,
+		`{"id":1}`,
+	} {
+		var req struct {
+			ID StrictUUID `json:"id"`
+		}
+
+		err := json.Unmarshal([]byte(data), &req)
+		if err == nil {
+			t.Errorf("expected error, but got nothing for %v", data)
+		}
+	}
+}
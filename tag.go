@@ -0,0 +1,41 @@
+package uuid
+
+import (
+	"errors"
+	"strconv"
+)
+
+// MaxTag is the largest tag EncodeTag can store.
+const MaxTag = 0x0f
+
+// EncodeTag stores a 4 bits tag (eg: the environment) in the 4 bits after the version bits,
+// the 16th character of the canonical format: xxxxxxxx-xxxx-4Txx-xxxx-xxxxxxxxxxxx
+// The result is still validated by FromString, DecodeTag returns the tag.
+// Next, NextN, Prev, Add and NextSameTime keep the tag, XOR and XORAll set it to the XOR of the tags.
+// @warning - The tag reduces the entropy of the uuid by 4 bits.
+// Returns an error for tags above MaxTag, Nil and malformed uuids.
+func EncodeTag(u UUID, tag uint8) (UUID, error) {
+	if tag > MaxTag {
+		return Nil, errors.New("uuid tag too big, max is " + strconv.Itoa(MaxTag) + ": " + strconv.Itoa(int(tag)))
+	}
+
+	b, err := u.Bytes()
+	if err != nil {
+		return Nil, err
+	}
+
+	b[6] = b[6]&0xf0 | tag
+
+	return UUID(string(encodeBytes(b[:]))), nil
+}
+
+// DecodeTag returns the tag stored by EncodeTag. There is no marker, so it returns the
+// 4 bits after the version bits of any uuid. Returns ErrNil for Nil and an error for malformed uuids.
+func DecodeTag(u UUID) (uint8, error) {
+	b, err := u.Bytes()
+	if err != nil {
+		return 0, err
+	}
+
+	return b[6] & 0x0f, nil
+}
@@ -0,0 +1,103 @@
+package uuid
+
+import (
+	"testing"
+)
+
+func TestEncodeTag(t *testing.T) {
+	for _, data := range []struct {
+		original UUID
+		tag      uint8
+		want     UUID
+	}{
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", tag: 0, want: "afe40693-8f63-4066-85f1-250a427f1db5"},
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", tag: 3, want: "afe40693-8f63-4366-85f1-250a427f1db5"},
+		{original: "AFE40693-8F63-4766-85F1-250A427F1DB5", tag: MaxTag, want: "afe40693-8f63-4f66-85f1-250a427f1db5"},
+	} {
+		got, err := EncodeTag(data.original, data.tag)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != data.want {
+			t.Errorf("want: %v, got: %v", data.want, got)
+		}
+		if _, err := FromString(got.String()); err != nil {
+			t.Error(err)
+		}
+
+		tag, err := DecodeTag(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tag != data.tag {
+			t.Errorf("want: %v, got: %v", data.tag, tag)
+		}
+	}
+
+	for tag := uint8(0); tag <= MaxTag; tag++ {
+		u, err := EncodeTag(NewV4(), tag)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := DecodeTag(u); err != nil || got != tag {
+			t.Errorf("want: %v, got: %v, %v", tag, got, err)
+		}
+	}
+}
+
+func TestEncodeTagChain(t *testing.T) {
+	a, err := EncodeTag("afe40693-8f63-4766-85f1-250a427f1db5", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := EncodeTag("43ae2f25-802d-4aae-be57-b7acefe336ac", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	next, err := a.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	nextSameTime, err := a.NextSameTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, u := range []UUID{next, nextSameTime} {
+		if tag, err := DecodeTag(u); err != nil || tag != 5 {
+			t.Errorf("want: 5, got: %v, %v", tag, err)
+		}
+	}
+
+	x, err := a.XOR(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tag, err := DecodeTag(x); err != nil || tag != 5^3 {
+		t.Errorf("want: %v, got: %v, %v", 5^3, tag, err)
+	}
+}
+
+func TestEncodeTagError(t *testing.T) {
+	for _, data := range []struct {
+		name     string
+		original UUID
+		tag      uint8
+	}{
+		{name: "tag too big", original: "afe40693-8f63-4766-85f1-250a427f1db5", tag: MaxTag + 1},
+		{name: "nil", original: Nil, tag: 1},
+		{name: "malformed", original: "asda", tag: 1},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			if _, err := EncodeTag(data.original, data.tag); err == nil {
+				t.Errorf("expected error, but got nothing for %v", data.original)
+			}
+		})
+	}
+
+	for _, orig := range []UUID{Nil, "asda"} {
+		if _, err := DecodeTag(orig); err == nil {
+			t.Errorf("expected error, but got nothing for %v", orig)
+		}
+	}
+}
@@ -0,0 +1,428 @@
+package uuid
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"slices"
+	"sync/atomic"
+	"time"
+)
+
+// gregorianOffset is the number of 100ns intervals between the start of the gregorian calendar (1582-10-15)
+// and the unix epoch, version 1 and 6 timestamps are counted from the former.
+const gregorianOffset = 0x01b21dd213814000
+
+// timeMarker is the bit after the variant bits (the 17th hex digit is a or b), set by NewTime and cleared by NewV4.
+const timeMarker byte = 0x20
+
+// ErrNotTimeUUID is returned by TimeUUIDToTime for uuids without the time marker if the check is enabled,
+// see SetTimeMarkerCheck.
+var ErrNotTimeUUID = errors.New("not a time uuid")
+
+// timeMarkerCheck enables the time marker check in TimeUUIDToTime, see SetTimeMarkerCheck.
+var timeMarkerCheck atomic.Bool
+
+// SetTimeMarkerCheck enables or disables the time marker check in TimeUUIDToTime.
+// It is disabled by default, as time uuids generated before the marker was introduced have it set only by chance.
+// Enable it to reject random and derived uuids once every stored time uuid has the marker.
+func SetTimeMarkerCheck(enabled bool) {
+	timeMarkerCheck.Store(enabled)
+}
+
+// IsTimeUUID reports whether u was generated by NewTime: it is a version 4 uuid with the RFC 4122 variant
+// and the time marker set, the bit after the variant bits (the 17th hex digit is a or b).
+// NewV4 and FromData clear the marker, so their uuids are not reported as time uuids.
+// @warning - The result is reliable only for uuids generated by this version or later: uuids generated before
+// the marker was introduced have it set by chance, about half of the random ones are reported as time uuids
+// and about half of the time ones are not. Uuids combined from other values (eg: XOR) may have it set as well.
+func (u UUID) IsTimeUUID() bool {
+	b, err := u.Bytes()
+	if err != nil {
+		return false
+	}
+
+	return isTimeUUID(b)
+}
+
+func isTimeUUID(b [size]byte) bool {
+	return b[6]&0xf0 == 0x40 && b[8]&0xe0 == 0x80|timeMarker
+}
+
+// ErrImplausibleTimestamp is returned by TimeUUIDToTimeInRange for timestamps outside of the expected range.
+var ErrImplausibleTimestamp = errors.New("implausible uuid timestamp")
+
+// TimeUUIDToTimeInRange is like TimeUUIDToTime, but returns ErrImplausibleTimestamp if the time is not within
+// min and max, inclusive, eg: for user-facing output where random timestamps like year 7302 must not leak.
+// The zero time for min or max means the default range between 2000 and 2100.
+func (u UUID) TimeUUIDToTimeInRange(min, max time.Time) (time.Time, error) {
+	if min.IsZero() {
+		min = plausibleTimeMin
+	}
+	if max.IsZero() {
+		max = plausibleTimeMax
+	}
+
+	if min.After(max) {
+		return time.Time{}.UTC(), errors.New("invalid time range, min is after max: " +
+			min.Format(time.RFC3339Nano) + " > " + max.Format(time.RFC3339Nano))
+	}
+
+	t, err := u.TimeUUIDToTime()
+	if err != nil {
+		return time.Time{}.UTC(), err
+	}
+
+	if !inTimeRange(t, min, max) {
+		return time.Time{}.UTC(), ErrImplausibleTimestamp
+	}
+
+	return t, nil
+}
+
+// inTimeRange reports whether t is within min and max, inclusive.
+func inTimeRange(t, min, max time.Time) bool {
+	return !t.Before(min) && !t.After(max)
+}
+
+// Age returns the time elapsed since the timestamp of the time uuid, eg: for expiring records.
+// Timestamps in the future result in negative ages, eg: for detecting clock skew.
+// Returns the same errors as TimeUUIDToTime.
+func (u UUID) Age() (time.Duration, error) {
+	t, err := u.TimeUUIDToTime()
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Since(t), nil
+}
+
+// TimeBucket returns the timestamp of the time uuid truncated to a multiple of d since the zero time,
+// eg: the hour of the uuid for d = time.Hour. Returns the same errors as TimeUUIDToTime, so non-time uuids
+// are rejected if the time marker check is enabled, see SetTimeMarkerCheck, and an error if d is not positive.
+func (u UUID) TimeBucket(d time.Duration) (time.Time, error) {
+	if d <= 0 {
+		return time.Time{}.UTC(), errors.New("invalid time bucket duration: " + d.String())
+	}
+
+	t, err := u.TimeUUIDToTime()
+	if err != nil {
+		return time.Time{}.UTC(), err
+	}
+
+	return t.Truncate(d), nil
+}
+
+// TruncateTime returns the smallest time uuid of the bucket of u (see TimeBucket), eg: as a partition key.
+// Its timestamp is the start of the bucket and its random bits are zero, only the version, variant and time marker
+// bits are set, eg: 016d6c27-a300-4000-a000-000000000000 for an hour. Returns the same errors as TimeBucket.
+func TruncateTime(u UUID, d time.Duration) (UUID, error) {
+	t, err := u.TimeBucket(d)
+	if err != nil {
+		return Nil, err
+	}
+
+	return minTimeUUID(Timestamp(t)), nil
+}
+
+// RewriteTime returns u with its timestamp replaced by t, the last 10 bytes including the version, variant
+// and random bits are kept, eg: for correcting creation times while keeping the uuids recognizable.
+// t is truncated to milliseconds. Returns ErrNil for Nil, an error for malformed uuids and times out of range
+// (see TimeInRange), and ErrNotTimeUUID for uuids without the time marker if the check is enabled,
+// see SetTimeMarkerCheck.
+func RewriteTime(u UUID, t time.Time) (UUID, error) {
+	b, err := u.Bytes()
+	if err != nil {
+		return Nil, err
+	}
+
+	if timeMarkerCheck.Load() && !isTimeUUID(b) {
+		return Nil, ErrNotTimeUUID
+	}
+
+	ms, err := TimestampE(t)
+	if err != nil {
+		return Nil, err
+	}
+
+	putTimestamp(b[:], ms)
+
+	return UUID(string(encodeBytes(b[:]))), nil
+}
+
+// ReplaceEntropy returns u with its random bits regenerated as in NewTime, keeping its timestamp,
+// eg: for anonymizing exported data without losing the order. Returns ErrNil for Nil, an error for malformed uuids
+// and ErrNotTimeUUID for uuids without the time marker if the check is enabled, see SetTimeMarkerCheck.
+func ReplaceEntropy(u UUID) (UUID, error) {
+	b, err := u.Bytes()
+	if err != nil {
+		return Nil, err
+	}
+
+	if timeMarkerCheck.Load() && !isTimeUUID(b) {
+		return Nil, ErrNotTimeUUID
+	}
+
+	if _, err := io.ReadFull(rand.Reader, b[6:]); err != nil {
+		panic(err)
+	}
+	setVersion(b[:])
+	b[8] |= timeMarker
+
+	return generated(UUID(string(encodeBytes(b[:]))), KindTime), nil
+}
+
+// ExtractEntropy returns the last 10 bytes of u, the random bits of time uuids (see NewTime), eg: for debugging.
+// The version, variant and time marker bits are masked out (set to zero), so only the random bits remain.
+// Other uuids are not rejected, the same bytes are returned for them. Returns ErrNil for Nil and an error
+// for malformed uuids.
+func (u UUID) ExtractEntropy() ([]byte, error) {
+	b, err := u.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	b[6] &= 0x0f
+	b[8] &^= 0xc0 | timeMarker
+
+	return b[6:], nil
+}
+
+// minTimeUUID returns the smallest time uuid with the timestamp ms, see NewTime.
+func minTimeUUID(ms uint64) UUID {
+	var b [size]byte
+	putTimestamp(b[:], ms)
+	setVersion(b[:])
+	b[8] |= timeMarker
+
+	return UUID(string(encodeBytes(b[:])))
+}
+
+// TimeV7 returns the creation time of an RFC 9562 version 7 uuid: the milliseconds since the unix epoch in the
+// first 48 bits. Returns ErrNil for Nil, ErrWrongVersion for other versions and an error for malformed uuids.
+func TimeV7(u UUID) (time.Time, error) {
+	b, err := u.Bytes()
+	if err != nil {
+		return time.Time{}.UTC(), err
+	}
+
+	if b[6]>>4 != 7 {
+		return time.Time{}.UTC(), ErrWrongVersion
+	}
+
+	ms, _ := u.timestamp()
+
+	return Time(ms), nil
+}
+
+// GregorianTime returns the creation time of a version 1 or 6 uuid: 100ns intervals since 1582-10-15 in 60 bits,
+// spread over the time_low, time_mid and time_hi fields for version 1 and in big-endian order for version 6.
+// Returns ErrNil for Nil, ErrWrongVersion for other versions and an error for malformed uuids.
+func GregorianTime(u UUID) (time.Time, error) {
+	b, err := u.Bytes()
+	if err != nil {
+		return time.Time{}.UTC(), err
+	}
+
+	if v := b[6] >> 4; v != 1 && v != 6 {
+		return time.Time{}.UTC(), ErrWrongVersion
+	}
+
+	ticks, _ := u.timeTicks()
+
+	return ticksToTime(ticks), nil
+}
+
+// Time returns the creation time of u according to its version: the gregorian timestamp of version 1 and 6 uuids,
+// the unix timestamp of version 7 uuids (see TimeV7) and the timestamp of time uuids (see TimeUUIDToTime) for
+// version 4. Version 4 uuids always require the time marker, regardless of SetTimeMarkerCheck, use TimeUUIDToTime
+// for time uuids generated before the marker was introduced.
+// Returns ErrNil for Nil, ErrNotTimeUUID for version 4 uuids without the time marker, ErrWrongVersion for other
+// versions and an error for malformed uuids.
+func (u UUID) Time() (time.Time, error) {
+	b, err := u.Bytes()
+	if err != nil {
+		return time.Time{}.UTC(), err
+	}
+
+	switch b[6] >> 4 {
+	case 1, 6:
+		return GregorianTime(u)
+	case 4:
+		if !isTimeUUID(b) {
+			return time.Time{}.UTC(), ErrNotTimeUUID
+		}
+		return u.TimeUUIDToTime()
+	case 7:
+		return TimeV7(u)
+	default:
+		return time.Time{}.UTC(), ErrWrongVersion
+	}
+}
+
+// ticksToTime converts 100ns intervals since the unix epoch, see timeTicks, into UTC time.
+func ticksToTime(ticks int64) time.Time {
+	const ticksPerSecond = int64(time.Second / 100)
+
+	return time.Unix(ticks/ticksPerSecond, ticks%ticksPerSecond*100).UTC()
+}
+
+// Between reports whether the timestamp of u is within the timestamps of start and end, inclusive.
+// The first 48 bits of the uuids are interpreted as milliseconds since the unix epoch, as in NewTime.
+// Returns ErrNil if any of them is Nil, an error for malformed uuids and for ranges where start is after end.
+// @warning - The first 48 bits of random uuids (eg: NewV4) are random too, Between is meaningless for them.
+func (u UUID) Between(start, end UUID) (bool, error) {
+	startMs, err := start.timestamp()
+	if err != nil {
+		return false, err
+	}
+
+	endMs, err := end.timestamp()
+	if err != nil {
+		return false, err
+	}
+
+	if startMs > endMs {
+		return false, errors.New("invalid time range, start is after end: " + start.String() + " > " + end.String())
+	}
+
+	ms, err := u.timestamp()
+	if err != nil {
+		return false, err
+	}
+
+	return startMs <= ms && ms <= endMs, nil
+}
+
+// TimeCompare compares the timestamps of a and b and returns -1 if a is older, +1 if a is newer. Uuids with the same
+// timestamp are compared as in Compare, so the result is 0 only for equal uuids.
+// Version 1 and 6 uuids are read according to RFC 9562, every other version as in NewTime (and version 7):
+// milliseconds since the unix epoch in the first 48 bits. Returns ErrNil if any of them is Nil and an error
+// for malformed uuids.
+func TimeCompare(a, b UUID) (int, error) {
+	at, err := a.timeTicks()
+	if err != nil {
+		return 0, err
+	}
+
+	bt, err := b.timeTicks()
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case at < bt:
+		return -1, nil
+	case at > bt:
+		return 1, nil
+	default:
+		return Compare(a, b), nil
+	}
+}
+
+// SortByTime sorts uuids in place from the oldest to the newest, see TimeCompare.
+// Nil uuids are moved to the front and malformed uuids to the end, ordered as in Compare.
+func SortByTime(uuids []UUID) {
+	slices.SortFunc(uuids, func(a, b UUID) int {
+		if c, err := TimeCompare(a, b); err == nil {
+			return c
+		}
+
+		_, aErr := a.timeTicks()
+		_, bErr := b.timeTicks()
+		switch {
+		case aErr != nil && bErr != nil:
+			return Compare(a, b)
+		case aErr != nil:
+			return sortPosition(a)
+		default:
+			return -sortPosition(b)
+		}
+	})
+}
+
+// sortPosition returns -1 for Nil which goes before the time uuids, and +1 for malformed uuids which go after them.
+func sortPosition(u UUID) int {
+	if u == Nil {
+		return -1
+	}
+
+	return 1
+}
+
+// SameTimePrefix reports whether the first 48 bits of a and b are equal, meaning the time uuids (see NewTime)
+// were created in the same millisecond. Returns ErrNil if any of them is Nil and an error for malformed uuids.
+func SameTimePrefix(a, b UUID) (bool, error) {
+	return SameTimeBucket(a, b, time.Millisecond)
+}
+
+// SameTimeBucket is like SameTimePrefix, but reports whether the timestamps are in the same bucket of duration d,
+// eg: the same second or minute, see TimeBucket. Returns an error if d is shorter than a millisecond.
+func SameTimeBucket(a, b UUID, d time.Duration) (bool, error) {
+	if d < time.Millisecond {
+		return false, errors.New("invalid time bucket duration: " + d.String())
+	}
+
+	aMs, err := a.timestamp()
+	if err != nil {
+		return false, err
+	}
+
+	bMs, err := b.timestamp()
+	if err != nil {
+		return false, err
+	}
+
+	if d == time.Millisecond || aMs == bMs {
+		return aMs == bMs, nil
+	}
+
+	return Time(aMs).Truncate(d).Equal(Time(bMs).Truncate(d)), nil
+}
+
+// timeTicks returns the timestamp of u in 100ns intervals since the unix epoch, negative for earlier times.
+// Version 1 and 6 uuids hold 60 bits gregorian timestamps, every other version is read as in timestamp.
+// Returns ErrNil for Nil and an error for malformed uuids.
+func (u UUID) timeTicks() (int64, error) {
+	b, err := u.Bytes()
+	if err != nil {
+		return 0, err
+	}
+
+	var ticks uint64
+	switch b[6] >> 4 {
+	case 1:
+		ticks = uint64(b[6]&0x0f)<<56 | uint64(b[7])<<48 | uint64(b[4])<<40 | uint64(b[5])<<32 |
+			uint64(b[0])<<24 | uint64(b[1])<<16 | uint64(b[2])<<8 | uint64(b[3])
+	case 6:
+		ticks = uint64(b[0])<<52 | uint64(b[1])<<44 | uint64(b[2])<<36 | uint64(b[3])<<28 |
+			uint64(b[4])<<20 | uint64(b[5])<<12 | uint64(b[6]&0x0f)<<8 | uint64(b[7])
+	default:
+		ms, _ := u.timestamp()
+		return int64(ms) * int64(time.Millisecond/100), nil
+	}
+
+	return int64(ticks) - gregorianOffset, nil
+}
+
+// timestamp returns the first 48 bits of u, the milliseconds since the unix epoch for time uuids.
+// Returns ErrNil for Nil and an error for malformed uuids.
+func (u UUID) timestamp() (uint64, error) {
+	b, err := u.Bytes()
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(b[0])<<40 | uint64(b[1])<<32 | uint64(b[2])<<24 |
+		uint64(b[3])<<16 | uint64(b[4])<<8 | uint64(b[5]), nil
+}
+
+// putTimestamp writes the 48 bits timestamp ms into the first 6 bytes of b, as in NewTime.
+func putTimestamp(b []byte, ms uint64) {
+	b[0] = byte(ms >> 40)
+	b[1] = byte(ms >> 32)
+	b[2] = byte(ms >> 24)
+	b[3] = byte(ms >> 16)
+	b[4] = byte(ms >> 8)
+	b[5] = byte(ms)
+}
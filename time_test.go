@@ -0,0 +1,887 @@
+package uuid
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestBetween(t *testing.T) {
+	base := time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+	start := NewTime(base)
+	end := NewTime(base.Add(time.Hour))
+
+	for _, data := range []struct {
+		original UUID
+		want     bool
+	}{
+		{original: NewTime(base.Add(-time.Millisecond)), want: false},
+		{original: NewTime(base), want: true},
+		{original: NewTime(base.Add(30 * time.Minute)), want: true},
+		{original: NewTime(base.Add(time.Hour)), want: true},
+		{original: NewTime(base.Add(time.Hour + time.Millisecond)), want: false},
+	} {
+		got, err := data.original.Between(start, end)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != data.want {
+			t.Errorf("want: %v, got: %v for %v", data.want, got, data.original)
+		}
+	}
+
+	// a single millisecond range
+	if got, err := start.Between(start, start); err != nil || !got {
+		t.Errorf("want: true, got: %v, %v", got, err)
+	}
+}
+
+func TestBetweenError(t *testing.T) {
+	base := time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+	start := NewTime(base)
+	end := NewTime(base.Add(time.Hour))
+
+	for _, data := range []struct {
+		name     string
+		original UUID
+		start    UUID
+		end      UUID
+		want     error
+	}{
+		{name: "nil uuid", original: Nil, start: start, end: end, want: ErrNil},
+		{name: "nil start", original: start, start: Nil, end: end, want: ErrNil},
+		{name: "nil end", original: start, start: start, end: Nil, want: ErrNil},
+		{name: "reversed", original: start, start: end, end: start},
+		{name: "malformed", original: "asda", start: start, end: end},
+		{name: "malformed start", original: start, start: "asda", end: end},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			_, err := data.original.Between(data.start, data.end)
+			if err == nil {
+				t.Fatalf("expected error, but got nothing for %v", data.original)
+			}
+			if data.want != nil && err != data.want {
+				t.Errorf("want: %v, got: %v", data.want, err)
+			}
+		})
+	}
+}
+
+func TestTimeCompare(t *testing.T) {
+	base := time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+	older := NewTime(base)
+	newer := NewTime(base.Add(time.Millisecond))
+
+	for _, data := range []struct {
+		a    UUID
+		b    UUID
+		want int
+	}{
+		{a: older, b: newer, want: -1},
+		{a: newer, b: older, want: 1},
+		{a: older, b: older, want: 0},
+		// same millisecond, byte order decides
+		{a: "0171cfe4-0880-4000-8000-000000000000", b: "0171cfe4-0880-4fff-bfff-ffffffffffff", want: -1},
+		// RFC 9562 test vectors of the same time in version 1, 6 and 7 layouts
+		{a: "6ba7b810-9dad-11d1-80b4-00c04fd430c8", b: "017f22e2-79b0-7cc3-98c4-dc0c0c07398f", want: -1},
+		{a: "c232ab00-9414-11ec-b3c8-9f6bdeced846", b: NewTime(time.Date(2022, 2, 22, 19, 22, 22, 1e6, time.UTC)), want: -1},
+		{a: "1ec9414c-232a-6b00-b3c8-9f6bdeced846", b: NewTime(time.Date(2022, 2, 22, 19, 22, 21, 999e6, time.UTC)), want: 1},
+	} {
+		got, err := TimeCompare(data.a, data.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != data.want {
+			t.Errorf("want: %v, got: %v for %v, %v", data.want, got, data.a, data.b)
+		}
+	}
+}
+
+func TestTimeCompareError(t *testing.T) {
+	u := NewTime(time.Now())
+
+	for _, data := range []struct {
+		name string
+		a    UUID
+		b    UUID
+	}{
+		{name: "nil a", a: Nil, b: u},
+		{name: "nil b", a: u, b: Nil},
+		{name: "malformed", a: u, b: "asda"},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			if _, err := TimeCompare(data.a, data.b); err == nil {
+				t.Errorf("expected error, but got nothing for %v, %v", data.a, data.b)
+			}
+		})
+	}
+}
+
+func TestSortByTime(t *testing.T) {
+	at := func(ms int) UUID {
+		return NewTime(time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC).Add(time.Duration(ms) * time.Millisecond))
+	}
+
+	v1Old := UUID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	v1 := UUID("c232ab00-9414-11ec-b3c8-9f6bdeced846")
+	v6 := UUID("1ec9414c-232a-6b00-b3c8-9f6bdeced846")
+	v7 := UUID("017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	before, after, later := at(-1), at(1), at(1000)
+
+	uuids := []UUID{later, "asda", v1, after, Nil, v7, v1Old, before, v6}
+	SortByTime(uuids)
+
+	// v1, v6 and v7 hold the same time, they are in byte order
+	want := []UUID{Nil, v1Old, before, v7, v6, v1, after, later, "asda"}
+	for i := range want {
+		if uuids[i] != want[i] {
+			t.Errorf("want: %v, got: %v", want, uuids)
+			break
+		}
+	}
+}
+
+func TestIsTimeUUID(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		if u := NewTime(time.Now()); !u.IsTimeUUID() {
+			t.Fatalf("want time uuid, got: %v", u)
+		}
+		if u := NewV4(); u.IsTimeUUID() {
+			t.Fatalf("want random uuid, got: %v", u)
+		}
+		if u := NewV4Insecure(); u.IsTimeUUID() {
+			t.Fatalf("want random uuid, got: %v", u)
+		}
+		if u, _ := NewV4WithPrefix([]byte{0xff}); u.IsTimeUUID() {
+			t.Fatalf("want random uuid, got: %v", u)
+		}
+	}
+
+	for _, data := range []struct {
+		original UUID
+		want     bool
+	}{
+		{original: "016d6c41-26bb-4766-a5f1-250a427f1db5", want: true},
+		{original: "016D6C41-26BB-4766-B5F1-250A427F1DB5", want: true},
+		{original: "016d6c41-26bb-4766-85f1-250a427f1db5", want: false},
+		{original: "016d6c41-26bb-4766-95f1-250a427f1db5", want: false},
+		{original: "016d6c41-26bb-1766-a5f1-250a427f1db5", want: false},
+		{original: "016d6c41-26bb-4766-e5f1-250a427f1db5", want: false},
+		// random uuid generated before the marker was introduced, it has the marker by chance
+		{original: "afe40693-8f63-4766-a5f1-250a427f1db5", want: true},
+		{original: Nil, want: false},
+		{original: Max, want: false},
+		{original: "asda", want: false},
+	} {
+		if got := data.original.IsTimeUUID(); got != data.want {
+			t.Errorf("want: %v, got: %v for %v", data.want, got, data.original)
+		}
+	}
+}
+
+func TestTimeUUIDToTimeMarker(t *testing.T) {
+	// time uuids generated before the marker was introduced are read by default
+	old := UUID("016d6c41-26bb-4766-85f1-250a427f1db5")
+	tm, err := old.TimeUUIDToTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2019, 9, 26, 6, 27, 52, 123000000, time.UTC); !want.Equal(tm) {
+		t.Errorf("want: %v, got: %v", want, tm)
+	}
+
+	SetTimeMarkerCheck(true)
+	defer SetTimeMarkerCheck(false)
+
+	for _, u := range []UUID{NewV4(), old, FromData([]byte("hello world"))} {
+		if _, err := u.TimeUUIDToTime(); err != ErrNotTimeUUID {
+			t.Errorf("want: %v, got: %v for %v", ErrNotTimeUUID, err, u)
+		}
+	}
+
+	if _, err := NewTime(time.Now()).TimeUUIDToTime(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFromDataNotTimeUUID(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		if u := FromData([]byte{byte(i)}); u.IsTimeUUID() {
+			t.Errorf("content addressed uuid has the time marker: %v", u)
+		}
+	}
+}
+
+func TestTimeMarkerRoundTrip(t *testing.T) {
+	u := NewTime(time.Now())
+
+	parsed, err := FromString(u.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !parsed.IsTimeUUID() {
+		t.Errorf("marker lost by FromString: %v", parsed)
+	}
+
+	b, err := json.Marshal(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fromJSON UUID
+	if err := json.Unmarshal(b, &fromJSON); err != nil {
+		t.Fatal(err)
+	}
+	if !fromJSON.IsTimeUUID() {
+		t.Errorf("marker lost by json: %v", fromJSON)
+	}
+
+	v, err := u.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fromSQL UUID
+	if err := fromSQL.Scan(v); err != nil {
+		t.Fatal(err)
+	}
+	if !fromSQL.IsTimeUUID() {
+		t.Errorf("marker lost by sql: %v", fromSQL)
+	}
+
+	next, err := u.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !next.IsTimeUUID() {
+		t.Errorf("marker lost by Next: %v", next)
+	}
+}
+
+func TestTimeUUIDToTimeError(t *testing.T) {
+	for _, data := range []struct {
+		name     string
+		original UUID
+		want     error
+	}{
+		{name: "nil", original: Nil, want: ErrNil},
+		{name: "short", original: "016d6c41"},
+		{name: "one character", original: "0"},
+		{name: "truncated", original: "016d6c41-26bb-4766-a5f1-250a427f1db"},
+		{name: "hash-like", original: "016d6c4126bb4766a5f1250a427f1db5"},
+		{name: "non-hex", original: "016d6c41-26bb-4766-a5f1-250a427f1dbx"},
+		{name: "missing dashes", original: "016d6c41026bb04766-a5f1-250a427f1db5"},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			tm, err := data.original.TimeUUIDToTime()
+			if err == nil {
+				t.Fatalf("expected error, but got nothing for %v", data.original)
+			}
+			if data.want != nil && err != data.want {
+				t.Errorf("want: %v, got: %v", data.want, err)
+			}
+			if !tm.IsZero() {
+				t.Errorf("want zero time, got: %v", tm)
+			}
+		})
+	}
+}
+
+func TestTimeUUIDToTimeInRange(t *testing.T) {
+	min := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	max := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, data := range []struct {
+		original time.Time
+		min      time.Time
+		max      time.Time
+		want     error
+	}{
+		{original: min, min: min, max: max},
+		{original: max, min: min, max: max},
+		{original: time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC), min: min, max: max},
+		{original: min.Add(-time.Millisecond), min: min, max: max, want: ErrImplausibleTimestamp},
+		{original: max.Add(time.Millisecond), min: min, max: max, want: ErrImplausibleTimestamp},
+		// default range
+		{original: time.Date(2019, 9, 26, 6, 27, 52, 123000000, time.UTC)},
+		{original: time.Date(1999, 12, 31, 23, 59, 59, 0, time.UTC), want: ErrImplausibleTimestamp},
+		{original: time.Date(7302, 1, 1, 0, 0, 0, 0, time.UTC), want: ErrImplausibleTimestamp},
+		{original: time.Date(1999, 12, 31, 23, 59, 59, 0, time.UTC), max: max, want: ErrImplausibleTimestamp},
+		{original: time.Date(2150, 1, 1, 0, 0, 0, 0, time.UTC), min: min, want: ErrImplausibleTimestamp},
+	} {
+		got, err := NewTime(data.original).TimeUUIDToTimeInRange(data.min, data.max)
+		if err != data.want {
+			t.Errorf("want: %v, got: %v for %v", data.want, err, data.original)
+			continue
+		}
+		if err == nil && !got.Equal(data.original) {
+			t.Errorf("want: %v, got: %v", data.original, got)
+		}
+	}
+
+	// the raw timestamp is still available
+	u := NewTime(time.Date(7302, 1, 1, 0, 0, 0, 0, time.UTC))
+	if _, err := u.TimeUUIDToTime(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestTimeUUIDToTimeInRangeError(t *testing.T) {
+	SetTimeMarkerCheck(true)
+	defer SetTimeMarkerCheck(false)
+
+	u := NewTime(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC))
+	min := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	max := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, data := range []struct {
+		name     string
+		original UUID
+		min      time.Time
+		max      time.Time
+		want     error
+	}{
+		{name: "reversed range", original: u, min: max, max: min},
+		{name: "nil", original: Nil, want: ErrNil},
+		{name: "no time marker", original: "016d6c41-26bb-4766-85f1-250a427f1db5", want: ErrNotTimeUUID},
+		{name: "malformed", original: "asda"},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			_, err := data.original.TimeUUIDToTimeInRange(data.min, data.max)
+			if err == nil {
+				t.Fatalf("expected error, but got nothing for %v", data.original)
+			}
+			if data.want != nil && err != data.want {
+				t.Errorf("want: %v, got: %v", data.want, err)
+			}
+		})
+	}
+}
+
+func TestAge(t *testing.T) {
+	for _, want := range []time.Duration{
+		time.Hour,
+		24 * time.Hour,
+		// in the future
+		-time.Hour,
+	} {
+		got, err := NewTime(time.Now().Add(-want)).Age()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got < want-time.Second || got > want+time.Second {
+			t.Errorf("want: %v, got: %v", want, got)
+		}
+	}
+}
+
+func TestAgeError(t *testing.T) {
+	SetTimeMarkerCheck(true)
+	defer SetTimeMarkerCheck(false)
+
+	for _, data := range []struct {
+		name     string
+		original UUID
+		want     error
+	}{
+		{name: "nil", original: Nil, want: ErrNil},
+		{name: "malformed", original: "asda"},
+		{name: "not time uuid", original: NewV4(), want: ErrNotTimeUUID},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			_, err := data.original.Age()
+			if err == nil {
+				t.Fatalf("expected error, but got nothing for %v", data.original)
+			}
+			if data.want != nil && err != data.want {
+				t.Errorf("want: %v, got: %v", data.want, err)
+			}
+		})
+	}
+}
+
+func TestTimeBucket(t *testing.T) {
+	u := UUID("016d6c41-26bb-4766-a5f1-250a427f1db5")
+
+	for _, data := range []struct {
+		d         time.Duration
+		want      time.Time
+		wantTrunc UUID
+	}{
+		{
+			d:         time.Hour,
+			want:      time.Date(2019, 9, 26, 6, 0, 0, 0, time.UTC),
+			wantTrunc: "016d6c27-a300-4000-a000-000000000000",
+		},
+		{
+			d:         time.Minute,
+			want:      time.Date(2019, 9, 26, 6, 27, 0, 0, time.UTC),
+			wantTrunc: "016d6c40-5b20-4000-a000-000000000000",
+		},
+		{
+			d:         time.Millisecond,
+			want:      time.Date(2019, 9, 26, 6, 27, 52, 123e6, time.UTC),
+			wantTrunc: "016d6c41-26bb-4000-a000-000000000000",
+		},
+	} {
+		got, err := u.TimeBucket(data.d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(data.want) {
+			t.Errorf("want: %v, got: %v", data.want, got)
+		}
+
+		trunc, err := TruncateTime(u, data.d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if trunc != data.wantTrunc {
+			t.Errorf("want: %v, got: %v", data.wantTrunc, trunc)
+		}
+
+		// the truncated uuid is a valid time uuid sorting before every uuid of the bucket
+		if !trunc.IsTimeUUID() || trunc.Validate() != nil {
+			t.Errorf("want valid time uuid, got: %v", trunc)
+		}
+		if c, _ := TimeCompare(trunc, NewTime(data.want)); c > 0 {
+			t.Errorf("want %v before every uuid of the bucket", trunc)
+		}
+	}
+}
+
+func TestTimeBucketError(t *testing.T) {
+	SetTimeMarkerCheck(true)
+	defer SetTimeMarkerCheck(false)
+
+	for _, data := range []struct {
+		name     string
+		original UUID
+		d        time.Duration
+		want     error
+	}{
+		{name: "nil", original: Nil, d: time.Hour, want: ErrNil},
+		{name: "malformed", original: "asda", d: time.Hour},
+		{name: "not time uuid", original: NewV4(), d: time.Hour, want: ErrNotTimeUUID},
+		{name: "zero duration", original: NewTime(time.Now()), d: 0},
+		{name: "negative duration", original: NewTime(time.Now()), d: -time.Hour},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			if _, err := data.original.TimeBucket(data.d); err == nil || data.want != nil && err != data.want {
+				t.Errorf("want: %v, got: %v", data.want, err)
+			}
+			if _, err := TruncateTime(data.original, data.d); err == nil || data.want != nil && err != data.want {
+				t.Errorf("want: %v, got: %v", data.want, err)
+			}
+		})
+	}
+}
+
+func TestRewriteTime(t *testing.T) {
+	for _, data := range []struct {
+		original UUID
+		time     time.Time
+		want     UUID
+	}{
+		{
+			original: "016d6c41-26bb-4766-a5f1-250a427f1db5",
+			time:     time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC),
+			want:     "0171d01b-9200-4766-a5f1-250a427f1db5",
+		},
+		{
+			// truncated to milliseconds
+			original: "016d6c41-26bb-4766-a5f1-250a427f1db5",
+			time:     time.Date(2020, 5, 1, 12, 0, 0, 999999, time.UTC),
+			want:     "0171d01b-9200-4766-a5f1-250a427f1db5",
+		},
+		{
+			original: "016D6C41-26BB-4766-A5F1-250A427F1DB5",
+			time:     time.Unix(0, 0),
+			want:     "00000000-0000-4766-a5f1-250a427f1db5",
+		},
+	} {
+		got, err := RewriteTime(data.original, data.time)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != data.want {
+			t.Errorf("want: %v, got: %v", data.want, got)
+		}
+	}
+
+	for i := 0; i < 100; i++ {
+		u := NewTime(time.Now())
+		want := time.Date(2001, 2, 3, 4, 5, 6, 7e6, time.UTC).Add(time.Duration(i) * time.Hour)
+
+		got, err := RewriteTime(u, want)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tm, err := got.TimeUUIDToTime()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !tm.Equal(want) {
+			t.Errorf("want: %v, got: %v", want, tm)
+		}
+
+		// the version, variant and random bits are kept
+		if got.HashLike()[12:] != u.HashLike()[12:] {
+			t.Errorf("want suffix of %v, got: %v", u, got)
+		}
+	}
+}
+
+func TestRewriteTimeError(t *testing.T) {
+	SetTimeMarkerCheck(true)
+	defer SetTimeMarkerCheck(false)
+
+	u := NewTime(time.Now())
+
+	for _, data := range []struct {
+		name     string
+		original UUID
+		time     time.Time
+		want     error
+	}{
+		{name: "nil", original: Nil, time: time.Now(), want: ErrNil},
+		{name: "malformed", original: "asda", time: time.Now()},
+		{name: "not time uuid", original: NewV4(), time: time.Now(), want: ErrNotTimeUUID},
+		{name: "before unix epoch", original: u, time: time.Unix(0, 0).Add(-time.Millisecond)},
+		{name: "after max time", original: u, time: MaxTime().Add(time.Millisecond)},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			_, err := RewriteTime(data.original, data.time)
+			if err == nil {
+				t.Fatalf("expected error, but got nothing for %v", data.original)
+			}
+			if data.want != nil && err != data.want {
+				t.Errorf("want: %v, got: %v", data.want, err)
+			}
+		})
+	}
+}
+
+func TestReplaceEntropy(t *testing.T) {
+	want := time.Date(2020, 5, 1, 12, 0, 0, 123e6, time.UTC)
+	u := NewTime(want)
+
+	seen := map[UUID]struct{}{u: {}}
+	for i := 0; i < 100; i++ {
+		got, err := ReplaceEntropy(u)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, ok := seen[got]; ok {
+			t.Fatalf("duplicate uuid: %v", got)
+		}
+		seen[got] = struct{}{}
+
+		if got[:13] != u[:13] {
+			t.Errorf("want prefix of %v, got: %v", u, got)
+		}
+		if !got.IsTimeUUID() || got.Validate() != nil {
+			t.Errorf("want valid time uuid, got: %v", got)
+		}
+
+		tm, err := got.TimeUUIDToTime()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !tm.Equal(want) {
+			t.Errorf("want: %v, got: %v", want, tm)
+		}
+	}
+}
+
+func TestReplaceEntropyError(t *testing.T) {
+	SetTimeMarkerCheck(true)
+	defer SetTimeMarkerCheck(false)
+
+	for _, data := range []struct {
+		name     string
+		original UUID
+		want     error
+	}{
+		{name: "nil", original: Nil, want: ErrNil},
+		{name: "malformed", original: "asda"},
+		{name: "not time uuid", original: NewV4(), want: ErrNotTimeUUID},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			got, err := ReplaceEntropy(data.original)
+			if err == nil {
+				t.Fatalf("expected error, but got nothing for %v", data.original)
+			}
+			if data.want != nil && err != data.want {
+				t.Errorf("want: %v, got: %v", data.want, err)
+			}
+			if got != Nil {
+				t.Errorf("want: %v, got: %v", Nil, got)
+			}
+		})
+	}
+}
+
+func TestExtractEntropy(t *testing.T) {
+	entropy := []byte{0xff, 0xfe, 0xfd, 0xfc, 0xfb, 0xfa, 0xf9, 0xf8, 0xf7, 0xf6}
+	u := newTimeFrom(bytes.NewReader(entropy), time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC))
+
+	if want := UUID("0171d01b-9200-4ffe-bdfc-fbfaf9f8f7f6"); u != want {
+		t.Errorf("want: %v, got: %v", want, u)
+	}
+
+	got, err := u.ExtractEntropy()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the injected bytes, but the version, variant and time marker bits are masked out
+	want := []byte{0x0f, 0xfe, 0x1d, 0xfc, 0xfb, 0xfa, 0xf9, 0xf8, 0xf7, 0xf6}
+	if !bytes.Equal(got, want) {
+		t.Errorf("want: %x, got: %x", want, got)
+	}
+}
+
+func TestExtractEntropyError(t *testing.T) {
+	for _, data := range []struct {
+		name     string
+		original UUID
+		want     error
+	}{
+		{name: "nil", original: Nil, want: ErrNil},
+		{name: "malformed", original: "asda"},
+		{name: "non-hex", original: "016d6c41-26bb-4766-a5f1-250a427f1dbx"},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			got, err := data.original.ExtractEntropy()
+			if err == nil {
+				t.Fatalf("expected error, but got nothing for %v", data.original)
+			}
+			if data.want != nil && err != data.want {
+				t.Errorf("want: %v, got: %v", data.want, err)
+			}
+			if got != nil {
+				t.Errorf("want nil, got: %x", got)
+			}
+		})
+	}
+}
+
+func TestSameTimePrefix(t *testing.T) {
+	base := time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, data := range []struct {
+		a    time.Time
+		b    time.Time
+		d    time.Duration
+		want bool
+	}{
+		{a: base, b: base, d: time.Millisecond, want: true},
+		{a: base, b: base.Add(999 * time.Microsecond), d: time.Millisecond, want: true},
+		{a: base, b: base.Add(time.Millisecond), d: time.Millisecond, want: false},
+		{a: base, b: base.Add(999 * time.Millisecond), d: time.Second, want: true},
+		{a: base.Add(-time.Millisecond), b: base, d: time.Second, want: false},
+		{a: base, b: base.Add(59 * time.Second), d: time.Minute, want: true},
+		{a: base, b: base.Add(time.Minute), d: time.Minute, want: false},
+		{a: base, b: base.Add(59 * time.Minute), d: time.Hour, want: true},
+	} {
+		a, b := NewTime(data.a), NewTime(data.b)
+
+		got, err := SameTimeBucket(a, b, data.d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != data.want {
+			t.Errorf("want: %v, got: %v for %v, %v, %v", data.want, got, data.a, data.b, data.d)
+		}
+
+		if data.d != time.Millisecond {
+			continue
+		}
+		got, err = SameTimePrefix(a, b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != data.want {
+			t.Errorf("want: %v, got: %v for %v, %v", data.want, got, data.a, data.b)
+		}
+	}
+}
+
+func TestSameTimePrefixError(t *testing.T) {
+	u := NewTime(time.Now())
+
+	for _, data := range []struct {
+		name string
+		a    UUID
+		b    UUID
+		d    time.Duration
+		want error
+	}{
+		{name: "nil a", a: Nil, b: u, d: time.Second, want: ErrNil},
+		{name: "nil b", a: u, b: Nil, d: time.Second, want: ErrNil},
+		{name: "malformed", a: u, b: "asda", d: time.Second},
+		{name: "zero duration", a: u, b: u, d: 0},
+		{name: "shorter than a millisecond", a: u, b: u, d: time.Microsecond},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			_, err := SameTimeBucket(data.a, data.b, data.d)
+			if err == nil {
+				t.Fatalf("expected error, but got nothing for %v, %v", data.a, data.b)
+			}
+			if data.want != nil && err != data.want {
+				t.Errorf("want: %v, got: %v", data.want, err)
+			}
+		})
+	}
+
+	if _, err := SameTimePrefix(Nil, u); err != ErrNil {
+		t.Errorf("want: %v, got: %v", ErrNil, err)
+	}
+}
+
+func TestTimeV7(t *testing.T) {
+	// RFC 9562 test vector
+	got, err := TimeV7("017f22e2-79b0-7cc3-98c4-dc0c0c07398f")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+
+	for _, data := range []struct {
+		name     string
+		original UUID
+		want     error
+	}{
+		{name: "nil", original: Nil, want: ErrNil},
+		{name: "malformed", original: "asda"},
+		{name: "version 4", original: NewTime(time.Now()), want: ErrWrongVersion},
+		{name: "version 1", original: "c232ab00-9414-11ec-b3c8-9f6bdeced846", want: ErrWrongVersion},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			_, err := TimeV7(data.original)
+			if err == nil {
+				t.Fatalf("expected error, but got nothing for %v", data.original)
+			}
+			if data.want != nil && err != data.want {
+				t.Errorf("want: %v, got: %v", data.want, err)
+			}
+		})
+	}
+}
+
+func TestUUIDTime(t *testing.T) {
+	for _, data := range []struct {
+		original UUID
+		want     time.Time
+	}{
+		// RFC 9562 test vectors of the same time
+		{original: "c232ab00-9414-11ec-b3c8-9f6bdeced846", want: time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)},
+		{original: "1EC9414C-232A-6B00-B3C8-9F6BDECED846", want: time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)},
+		{original: "017f22e2-79b0-7cc3-98c4-dc0c0c07398f", want: time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)},
+		{original: "6ba7b810-9dad-11d1-80b4-00c04fd430c8", want: time.Date(1998, 2, 4, 22, 13, 53, 151182400, time.UTC)},
+		{original: "016d6c41-26bb-4766-a5f1-250a427f1db5", want: time.Date(2019, 9, 26, 6, 27, 52, 123e6, time.UTC)},
+	} {
+		got, err := data.original.Time()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(data.want) {
+			t.Errorf("want: %v, got: %v for %v", data.want, got, data.original)
+		}
+	}
+}
+
+func TestUUIDTimeError(t *testing.T) {
+	SetTimeMarkerCheck(true)
+	defer SetTimeMarkerCheck(false)
+
+	for _, data := range []struct {
+		name     string
+		original UUID
+		want     error
+	}{
+		{name: "nil", original: Nil, want: ErrNil},
+		{name: "malformed", original: "asda"},
+		{name: "version 4 without time marker", original: "016d6c41-26bb-4766-85f1-250a427f1db5", want: ErrNotTimeUUID},
+		{name: "version 3", original: "6fa459ea-ee8a-3ca4-894e-db77e160355e", want: ErrWrongVersion},
+		{name: "version 5", original: "886313e1-3b8a-5372-9b90-0c9aee199e5d", want: ErrWrongVersion},
+		{name: "max", original: Max, want: ErrWrongVersion},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			_, err := data.original.Time()
+			if err == nil {
+				t.Fatalf("expected error, but got nothing for %v", data.original)
+			}
+			if data.want != nil && err != data.want {
+				t.Errorf("want: %v, got: %v", data.want, err)
+			}
+		})
+	}
+}
+
+func TestUUIDTimeRandom(t *testing.T) {
+	// the time marker is required even if the check is disabled
+	for i := 0; i < 100; i++ {
+		u := NewV4()
+		if got, err := u.Time(); err != ErrNotTimeUUID {
+			t.Fatalf("want: %v, got: %v, %v for %v", ErrNotTimeUUID, got, err, u)
+		}
+	}
+
+	if _, err := UUID("016d6c41-26bb-4766-85f1-250a427f1db5").Time(); err != ErrNotTimeUUID {
+		t.Errorf("want: %v, got: %v", ErrNotTimeUUID, err)
+	}
+}
+
+func TestGregorianTime(t *testing.T) {
+	for _, data := range []struct {
+		original UUID
+		want     time.Time
+	}{
+		// RFC 9562 test vectors
+		{original: "c232ab00-9414-11ec-b3c8-9f6bdeced846", want: time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)},
+		{original: "1ec9414c-232a-6b00-b3c8-9f6bdeced846", want: time.Date(2022, 2, 22, 19, 22, 22, 0, time.UTC)},
+		// RFC 4122 namespace DNS
+		{original: "6ba7b810-9dad-11d1-80b4-00c04fd430c8", want: time.Date(1998, 2, 4, 22, 13, 53, 151182400, time.UTC)},
+		// the start of the gregorian calendar
+		{original: "00000000-0000-1000-8000-000000000000", want: time.Date(1582, 10, 15, 0, 0, 0, 0, time.UTC)},
+		{original: "00000000-0000-6000-8000-000000000000", want: time.Date(1582, 10, 15, 0, 0, 0, 0, time.UTC)},
+		// the end of the 60 bits
+		{original: "ffffffff-ffff-1fff-8000-000000000000", want: time.Date(5236, 3, 31, 21, 21, 0, 684697500, time.UTC)},
+	} {
+		got, err := GregorianTime(data.original)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(data.want) {
+			t.Errorf("want: %v, got: %v for %v", data.want, got, data.original)
+		}
+	}
+}
+
+func TestGregorianTimeError(t *testing.T) {
+	for _, data := range []struct {
+		name     string
+		original UUID
+		want     error
+	}{
+		{name: "nil", original: Nil, want: ErrNil},
+		{name: "malformed", original: "asda"},
+		{name: "version 4", original: NewTime(time.Now()), want: ErrWrongVersion},
+		{name: "version 7", original: "017f22e2-79b0-7cc3-98c4-dc0c0c07398f", want: ErrWrongVersion},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			_, err := GregorianTime(data.original)
+			if err == nil {
+				t.Fatalf("expected error, but got nothing for %v", data.original)
+			}
+			if data.want != nil && err != data.want {
+				t.Errorf("want: %v, got: %v", data.want, err)
+			}
+		})
+	}
+}
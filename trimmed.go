@@ -0,0 +1,51 @@
+package uuid
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// TrimmedUUID is a UUID which trims leading and trailing whitespace when unmarshalled, see FromStringTrimmed,
+// eg: for ids copied from spreadsheets. It is marshalled in canonical format like UUID.
+type TrimmedUUID UUID
+
+func (u TrimmedUUID) UUID() UUID {
+	return UUID(u)
+}
+
+func (u TrimmedUUID) String() string {
+	return string(u)
+}
+
+func (u TrimmedUUID) MarshalText() ([]byte, error) {
+	return UUID(u).MarshalText()
+}
+
+func (u *TrimmedUUID) UnmarshalText(text []byte) error {
+	uid, err := FromStringTrimmed(string(text))
+	if err != nil {
+		return err
+	}
+
+	*u = TrimmedUUID(uid)
+
+	return nil
+}
+
+func (u TrimmedUUID) MarshalJSON() ([]byte, error) {
+	return UUID(u).MarshalJSON()
+}
+
+func (u *TrimmedUUID) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		*u = TrimmedUUID(Nil)
+		return nil
+	}
+
+	str, err := strconv.Unquote(string(b))
+	if err != nil {
+		return errInvalid("invalid json value for uuid (must be string or null): " + string(b))
+	}
+
+	return u.UnmarshalText([]byte(str))
+}
@@ -0,0 +1,84 @@
+package uuid
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTrimmedUUIDJSON(t *testing.T) {
+	want := UUID("afe40693-8f63-4766-85f1-250a427f1db5")
+
+	for _, orig := range trimmedTests {
+		b, err := json.Marshal(map[string]string{"id": orig})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// decode into a previously used value
+		req := struct {
+			ID TrimmedUUID `json:"id"`
+		}{ID: TrimmedUUID(NewV4())}
+
+		if err := json.Unmarshal(b, &req); err != nil {
+			t.Fatal(err)
+		}
+		if req.ID.UUID() != want {
+			t.Errorf("want: %v, got: %v", want, req.ID)
+		}
+
+		var text TrimmedUUID
+		if err := text.UnmarshalText([]byte(orig)); err != nil || text.UUID() != want {
+			t.Errorf("want: %v, got: %v, %v", want, text, err)
+		}
+
+		// UUID is strict
+		var u UUID
+		if err := json.Unmarshal(b, &struct {
+			ID *UUID `json:"id"`
+		}{ID: &u}); err == nil {
+			t.Errorf("expected error, but got nothing for %q", orig)
+		}
+
+		// marshalled in canonical format
+		b, err = json.Marshal(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := `{"id":"` + want.String() + `"}`; want != string(b) {
+			t.Errorf("want: %s, got: %s", want, b)
+		}
+	}
+
+	for _, orig := range []string{`{"id":null}`, `{"id":""}`, `{"id":" \n"}`} {
+		req := struct {
+			ID TrimmedUUID `json:"id"`
+		}{ID: TrimmedUUID(NewV4())}
+
+		if err := json.Unmarshal([]byte(orig), &req); err != nil {
+			t.Fatal(err)
+		}
+		if req.ID.UUID() != Nil {
+			t.Errorf("want: %v, got: %v", Nil, req.ID)
+		}
+	}
+}
+
+func TestTrimmedUUIDError(t *testing.T) {
+	for _, orig := range append([]string{
+		"afe40693-8f63-4766- 85f1-250a427f1db5",
+		"afe406938f63476685f1250a427f1db5",
+	}, testErrors...) {
+		var u TrimmedUUID
+		if err := u.UnmarshalText([]byte(orig)); err == nil {
+			t.Errorf("expected error, but got nothing for %v", orig)
+		}
+		if err := json.Unmarshal([]byte(`"`+orig+`"`), &u); err == nil {
+			t.Errorf("expected error, but got nothing for %v", orig)
+		}
+	}
+
+	var u TrimmedUUID
+	if err := json.Unmarshal([]byte(`1`), &u); err == nil {
+		t.Error("expected error, but got nothing for number")
+	}
+}
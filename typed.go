@@ -0,0 +1,80 @@
+package uuid
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// MaxTypedPrefix is the maximum length of the prefix of typed ids.
+const MaxTypedPrefix = 16
+
+// EncodeTyped returns a self-describing typed id: the prefix and the uuid in base58 format separated by
+// an underscore, eg: usr_NikCFgDe7RmG2a8p8nnnua
+// The prefix must start with a lowercase letter, contain only lowercase letters and digits, and be at most
+// MaxTypedPrefix characters long. Returns an empty string for Nil.
+func EncodeTyped(prefix string, u UUID) (string, error) {
+	if err := validateTypedPrefix(prefix); err != nil {
+		return "", err
+	}
+
+	if u == Nil {
+		return "", nil
+	}
+
+	body := u.Base58()
+	if body == "" {
+		return "", errInvalid("invalid uuid: " + u.String())
+	}
+
+	return prefix + "_" + body, nil
+}
+
+// DecodeTyped parses a typed id returned by EncodeTyped, eg: usr_NikCFgDe7RmG2a8p8nnnua
+// The uuid is validated as in FromBase58. Returns an empty prefix and Nil for an empty string.
+func DecodeTyped(str string) (string, UUID, error) {
+	if str == "" {
+		return "", Nil, nil
+	}
+
+	prefix, body, ok := strings.Cut(str, "_")
+	if !ok {
+		return "", Nil, errInvalid("invalid typed id, missing prefix: " + str)
+	}
+
+	if err := validateTypedPrefix(prefix); err != nil {
+		return "", Nil, err
+	}
+
+	if body == "" {
+		return "", Nil, errInvalid("invalid typed id, missing uuid: " + str)
+	}
+
+	u, err := FromBase58(body)
+	if err != nil {
+		return "", Nil, err
+	}
+
+	return prefix, u, nil
+}
+
+func validateTypedPrefix(prefix string) error {
+	if prefix == "" {
+		return errors.New("empty typed id prefix")
+	}
+
+	if len(prefix) > MaxTypedPrefix {
+		return errors.New("typed id prefix too long, max length is " + strconv.Itoa(MaxTypedPrefix) + ": " + prefix)
+	}
+
+	for i := 0; i < len(prefix); i++ {
+		c := prefix[i]
+		if 'a' <= c && c <= 'z' || i > 0 && '0' <= c && c <= '9' {
+			continue
+		}
+
+		return errors.New("invalid typed id prefix, must be lowercase letters and digits starting with a letter: " + prefix)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package uuid
+
+import (
+	"testing"
+)
+
+func TestEncodeTyped(t *testing.T) {
+	for _, data := range []struct {
+		prefix   string
+		original UUID
+		want     string
+	}{
+		{prefix: "usr", original: "", want: ""},
+		{prefix: "usr", original: "afe40693-8f63-4766-85f1-250a427f1db5", want: "usr_NikCFgDe7RmG2a8p8nnnua"},
+		{prefix: "o2", original: "43ae2f25-802d-4aae-be57-b7acefe336ac", want: "o2_9MjWkHnYB6dG4kyRjpo6ew"},
+		{prefix: "abcdefghijklmnop", original: "43ae2f25-802d-4aae-be57-b7acefe336ac", want: "abcdefghijklmnop_9MjWkHnYB6dG4kyRjpo6ew"},
+	} {
+		got, err := EncodeTyped(data.prefix, data.original)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data.want != got {
+			t.Errorf("want: %s, got: %s", data.want, got)
+		}
+
+		prefix, back, err := DecodeTyped(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back != data.original {
+			t.Errorf("want: %s, got: %s", data.original, back)
+		}
+		if data.original != Nil && prefix != data.prefix {
+			t.Errorf("want: %s, got: %s", data.prefix, prefix)
+		}
+	}
+}
+
+func TestEncodeTypedError(t *testing.T) {
+	u := UUID("afe40693-8f63-4766-85f1-250a427f1db5")
+
+	for _, data := range []struct {
+		name   string
+		prefix string
+		uuid   UUID
+	}{
+		{name: "empty prefix", prefix: "", uuid: u},
+		{name: "too long prefix", prefix: "abcdefghijklmnopq", uuid: u},
+		{name: "uppercase prefix", prefix: "Usr", uuid: u},
+		{name: "underscore in prefix", prefix: "us_r", uuid: u},
+		{name: "leading digit", prefix: "1usr", uuid: u},
+		{name: "malformed uuid", prefix: "usr", uuid: "asda"},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			_, err := EncodeTyped(data.prefix, data.uuid)
+			if err == nil {
+				t.Errorf("expected error, but got nothing for %v %v", data.prefix, data.uuid)
+			}
+		})
+	}
+}
+
+func TestDecodeTypedError(t *testing.T) {
+	for _, data := range []struct {
+		name     string
+		original string
+	}{
+		{name: "missing prefix", original: "NikCFgDe7RmG2a8p8nnnua"},
+		{name: "empty prefix", original: "_NikCFgDe7RmG2a8p8nnnua"},
+		{name: "invalid prefix", original: "USR_NikCFgDe7RmG2a8p8nnnua"},
+		{name: "too long prefix", original: "abcdefghijklmnopq_NikCFgDe7RmG2a8p8nnnua"},
+		{name: "missing uuid", original: "usr_"},
+		{name: "double separator", original: "usr__NikCFgDe7RmG2a8p8nnnua"},
+		{name: "invalid uuid", original: "usr_NikCFgDe7RmG2a8p8nnnu0"},
+		{name: "invalid version bit", original: "usr_Ky6byeeMQgecGvuaeFGuHn"},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			_, _, err := DecodeTyped(data.original)
+			if err == nil {
+				t.Errorf("expected error, but got nothing for %v", data.original)
+			}
+		})
+	}
+}
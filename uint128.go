@@ -0,0 +1,104 @@
+package uuid
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// Uint128 is an unsigned 128 bits integer, Hi holds the most significant 64 bits.
+// All arithmetic wraps around on overflow.
+type Uint128 struct {
+	Hi uint64
+	Lo uint64
+}
+
+// Add returns v + w.
+func (v Uint128) Add(w Uint128) Uint128 {
+	lo, carry := bits.Add64(v.Lo, w.Lo, 0)
+	hi, _ := bits.Add64(v.Hi, w.Hi, carry)
+
+	return Uint128{Hi: hi, Lo: lo}
+}
+
+// Sub returns v - w.
+func (v Uint128) Sub(w Uint128) Uint128 {
+	lo, borrow := bits.Sub64(v.Lo, w.Lo, 0)
+	hi, _ := bits.Sub64(v.Hi, w.Hi, borrow)
+
+	return Uint128{Hi: hi, Lo: lo}
+}
+
+// Mul64 returns v * n.
+func (v Uint128) Mul64(n uint64) Uint128 {
+	hi, lo := bits.Mul64(v.Lo, n)
+
+	return Uint128{Hi: hi + v.Hi*n, Lo: lo}
+}
+
+// Cmp compares v and w and returns -1 if v < w, 0 if v == w and +1 if v > w.
+func (v Uint128) Cmp(w Uint128) int {
+	switch {
+	case v.Hi < w.Hi:
+		return -1
+	case v.Hi > w.Hi:
+		return 1
+	case v.Lo < w.Lo:
+		return -1
+	case v.Lo > w.Lo:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Xor returns the bitwise XOR of v and w.
+func (v Uint128) Xor(w Uint128) Uint128 {
+	return Uint128{Hi: v.Hi ^ w.Hi, Lo: v.Lo ^ w.Lo}
+}
+
+// IsZero reports whether v is 0.
+func (v Uint128) IsZero() bool {
+	return v.Hi == 0 && v.Lo == 0
+}
+
+// Uint128 returns the uuid as a 128 bits big-endian unsigned integer. Returns 0 for Nil.
+func (u UUID) Uint128() (Uint128, error) {
+	if u == Nil {
+		return Uint128{}, nil
+	}
+
+	b, err := u.decode()
+	if err != nil {
+		return Uint128{}, errInvalid("invalid uuid: " + u.String())
+	}
+
+	return uint128FromBytes(b), nil
+}
+
+// FromUint128 converts a 128 bits unsigned integer into a uuid, the reverse of UUID.Uint128.
+// If setBits is true, the version and variant bits are set as in NewV4, otherwise the bits are kept as is
+// and the result is only validated as in LenientFromString. 0 results in Nil in both cases.
+func FromUint128(v Uint128, setBits bool) UUID {
+	if v.IsZero() {
+		return Nil
+	}
+
+	b := v.bytes()
+	if setBits {
+		setVersion(b[:])
+	}
+
+	return UUID(string(encodeBytes(b[:])))
+}
+
+func uint128FromBytes(b [size]byte) Uint128 {
+	return Uint128{Hi: binary.BigEndian.Uint64(b[0:8]), Lo: binary.BigEndian.Uint64(b[8:16])}
+}
+
+func (v Uint128) bytes() [size]byte {
+	b := [size]byte{}
+	binary.BigEndian.PutUint64(b[0:8], v.Hi)
+	binary.BigEndian.PutUint64(b[8:16], v.Lo)
+
+	return b
+}
@@ -0,0 +1,94 @@
+package uuid
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUint128Arithmetic(t *testing.T) {
+	max := Uint128{Hi: math.MaxUint64, Lo: math.MaxUint64}
+	one := Uint128{Lo: 1}
+
+	for _, data := range []struct {
+		name string
+		got  Uint128
+		want Uint128
+	}{
+		{name: "add", got: Uint128{Hi: 1, Lo: 2}.Add(Uint128{Hi: 3, Lo: 4}), want: Uint128{Hi: 4, Lo: 6}},
+		{name: "add carry", got: Uint128{Lo: math.MaxUint64}.Add(one), want: Uint128{Hi: 1}},
+		{name: "add overflow", got: max.Add(one), want: Uint128{}},
+		{name: "sub", got: Uint128{Hi: 4, Lo: 6}.Sub(Uint128{Hi: 3, Lo: 4}), want: Uint128{Hi: 1, Lo: 2}},
+		{name: "sub borrow", got: Uint128{Hi: 1}.Sub(one), want: Uint128{Lo: math.MaxUint64}},
+		{name: "sub underflow", got: Uint128{}.Sub(one), want: max},
+		{name: "mul", got: Uint128{Hi: 2, Lo: 3}.Mul64(5), want: Uint128{Hi: 10, Lo: 15}},
+		{name: "mul carry", got: Uint128{Lo: math.MaxUint64}.Mul64(2), want: Uint128{Hi: 1, Lo: math.MaxUint64 - 1}},
+		{name: "mul overflow", got: max.Mul64(2), want: Uint128{Hi: math.MaxUint64, Lo: math.MaxUint64 - 1}},
+		{name: "mul zero", got: max.Mul64(0), want: Uint128{}},
+		{name: "xor", got: Uint128{Hi: 0xff00, Lo: 0x0ff0}.Xor(Uint128{Hi: 0x0ff0, Lo: 0x0ff0}), want: Uint128{Hi: 0xf0f0}},
+	} {
+		if data.got != data.want {
+			t.Errorf("%v want: %+v, got: %+v", data.name, data.want, data.got)
+		}
+	}
+}
+
+func TestUint128Cmp(t *testing.T) {
+	for _, data := range []struct {
+		a, b Uint128
+		want int
+	}{
+		{a: Uint128{}, b: Uint128{}, want: 0},
+		{a: Uint128{Hi: 1}, b: Uint128{Lo: math.MaxUint64}, want: 1},
+		{a: Uint128{Lo: math.MaxUint64}, b: Uint128{Hi: 1}, want: -1},
+		{a: Uint128{Hi: 1, Lo: 1}, b: Uint128{Hi: 1, Lo: 2}, want: -1},
+		{a: Uint128{Hi: 1, Lo: 2}, b: Uint128{Hi: 1, Lo: 1}, want: 1},
+	} {
+		if got := data.a.Cmp(data.b); got != data.want {
+			t.Errorf("%+v.Cmp(%+v) want: %v, got: %v", data.a, data.b, data.want, got)
+		}
+	}
+}
+
+func TestUUIDUint128(t *testing.T) {
+	u := UUID("afe40693-8f63-4766-85f1-250a427f1db5")
+
+	v, err := u.Uint128()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Uint128{Hi: 0xafe406938f634766, Lo: 0x85f1250a427f1db5}); want != v {
+		t.Errorf("want: %+v, got: %+v", want, v)
+	}
+
+	if back := FromUint128(v, false); back != u {
+		t.Errorf("want: %v, got: %v", u, back)
+	}
+
+	if v, err := Nil.Uint128(); err != nil || !v.IsZero() {
+		t.Errorf("want zero for Nil, got: %+v, %v", v, err)
+	}
+
+	if _, err := UUID("asda").Uint128(); err == nil {
+		t.Error("expected error, but got nothing for malformed uuid")
+	}
+}
+
+func TestFromUint128(t *testing.T) {
+	v := Uint128{Hi: 0x9999999999996999, Lo: 0x1999250a427f1db5}
+
+	if got, want := FromUint128(v, false), UUID("99999999-9999-6999-1999-250a427f1db5"); want != got {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+
+	got := FromUint128(v, true)
+	if want := UUID("99999999-9999-4999-9999-250a427f1db5"); want != got {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+	if _, err := FromString(got.String()); err != nil {
+		t.Error(err)
+	}
+
+	if got := FromUint128(Uint128{}, true); got != Nil {
+		t.Errorf("want: Nil, got: %v", got)
+	}
+}
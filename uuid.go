@@ -4,14 +4,15 @@ import (
 	"bytes"
 	"crypto/rand"
 	"database/sql/driver"
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"math/big"
-	"regexp"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -19,54 +20,99 @@ type UUID string
 
 const size = 16
 
-var maxTime uint64
+// MaxTimestamp is the biggest timestamp (see Timestamp) time uuids can hold in their first 48 bits,
+// the time is 10889-08-02T05:31:50.655Z.
+const MaxTimestamp uint64 = 1<<48 - 1
+
 var bigPrime *big.Int
+var primeStep Uint128
 
 func init() {
 	bigPrime = new(big.Int)
 	// 14 bytes long prime number
 	_ = bigPrime.UnmarshalText([]byte("908070605040302010203040506070809"))
-
-	maxTimeUUID, _ := FromString("ffffffff-ffff-1000-a000-000000000000")
-	t, _ := maxTimeUUID.TimeUUIDToTime()
-	maxTime = Timestamp(t)
+	var pb [size]byte
+	bigPrime.FillBytes(pb[:])
+	primeStep = uint128FromBytes(pb)
 }
 
 var (
 	Nil        UUID
 	byteGroups = []int{8, 4, 4, 4, 12}
+	unixEpoch  = time.Unix(0, 0).UTC()
 )
 
-var uuidRegex = regexp.MustCompile(`(?i)^[0-9a-f]{8}-[0-9a-f]{4}-[1-5][0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+// Max is the RFC 9562 Max UUID with all bits set, eg: an upper bound in range scans.
+// It is accepted by the parsers despite its invalid version and variant bits.
+const Max UUID = "ffffffff-ffff-ffff-ffff-ffffffffffff"
+
+// ErrNil is returned by Bytes for Nil.
+var ErrNil = errors.New("nil uuid")
+
+// ErrInvalidUUID is wrapped by the errors of the parsers, unmarshallers and Scan for invalid input,
+// so it can be checked with errors.Is.
+var ErrInvalidUUID = errors.New("invalid uuid")
+
+// invalidError is an error message wrapping ErrInvalidUUID.
+type invalidError string
+
+func (e invalidError) Error() string {
+	return string(e)
+}
+
+func (e invalidError) Unwrap() error {
+	return ErrInvalidUUID
+}
+
+// errInvalid returns an error with the message msg, wrapping ErrInvalidUUID.
+func errInvalid(msg string) error {
+	return invalidError(msg)
+}
 
 // FromString parses uuid in canonical format, eg: afe40693-8f63-4766-85f1-250a427f1db5
+// The version must be between 1 and 5 and the variant must be RFC 4122, except for Nil and Max,
+// invalid input results in a *ParseError. Leading and trailing whitespace is rejected, see FromStringTrimmed.
 func FromString(str string) (UUID, error) {
 	if str == "" || str == "00000000-0000-0000-0000-000000000000" {
 		return Nil, nil
 	}
 
-	if !uuidRegex.MatchString(str) {
-		return Nil, errors.New("invalid uuid: " + str)
+	if strings.EqualFold(str, string(Max)) {
+		return Max, nil
+	}
+
+	if err := checkFormat(str, false); err != nil {
+		return Nil, err
 	}
 
 	return UUID(strings.ToLower(str)), nil
 }
 
+// Validate runs the same checks as FromString on u, eg: for uuids created by casting a string to UUID,
+// and returns the same error. Nil is valid.
+func (u UUID) Validate() error {
+	_, err := FromString(string(u))
+
+	return err
+}
+
 // FromHashLike parses uuid in hash format, eg: afe406938f63476685f1250a427f1db5
+// It is validated as in FromString, invalid input results in a *ParseError.
 func FromHashLike(str string) (UUID, error) {
 	if str == "" || str == "00000000000000000000000000000000" {
 		return Nil, nil
 	}
 
-	if len(str) != 32 {
-		return Nil, errors.New("invalid uuid: " + str)
+	if strings.EqualFold(str, "ffffffffffffffffffffffffffffffff") {
+		return Max, nil
 	}
 
-	uuid := str[0:8] + "-" + str[8:12] + "-" + str[12:16] + "-" + str[16:20] + "-" + str[20:]
-	if !uuidRegex.MatchString(uuid) {
-		return Nil, errors.New("invalid uuid: " + str)
+	if err := checkFormat(str, true); err != nil {
+		return Nil, err
 	}
 
+	uuid := str[0:8] + "-" + str[8:12] + "-" + str[12:16] + "-" + str[16:20] + "-" + str[20:]
+
 	return UUID(strings.ToLower(uuid)), nil
 }
 
@@ -76,40 +122,70 @@ func NewV4() UUID {
 		panic(err)
 	}
 
-	// set version to v4
-	const v4 byte = 4
-	u[6] = (u[6] & 0x0f) | (v4 << 4)
-	// set variant to RFC4122
-	u[8] = u[8]&(0xff>>2) | (0x02 << 6)
+	setVersion(u[:])
+	u[8] &^= timeMarker
 
-	return UUID(string(encodeBytes(u[:])))
+	return generated(UUID(string(encodeBytes(u[:]))), KindV4)
 }
 
+// NewTime generates a time uuid: the first 48 bits are the milliseconds of t since the unix epoch, followed
+// by random bits, so time uuids are sorted by their timestamp.
+// It panics for times before the unix epoch (1970-01-01T00:00:00Z) like Timestamp, and for times too big
+// to fit into 48 bits, see TimeInRange.
 func NewTime(t time.Time) UUID {
+	return newTimeFrom(rand.Reader, t)
+}
+
+// newTimeFrom is like NewTime, but reads the random bits from r.
+func newTimeFrom(r io.Reader, t time.Time) UUID {
 	u := [size]byte{}
-	if _, err := io.ReadFull(rand.Reader, u[6:]); err != nil {
+	if _, err := io.ReadFull(r, u[6:]); err != nil {
 		panic(err)
 	}
 
 	ms := Timestamp(t)
-
-	if ms > maxTime {
+	if !TimeInRange(t) {
 		panic("time too big")
 	}
 
-	const v4 byte = 4
-
 	u[0] = byte(ms >> 40)
 	u[1] = byte(ms >> 32)
 	u[2] = byte(ms >> 24)
 	u[3] = byte(ms >> 16)
 	u[4] = byte(ms >> 8)
 	u[5] = byte(ms)
-	u[6] = (u[6] & 0x0f) | (v4 << 4)
-	// set variant to RFC4122
-	u[8] = u[8]&(0xff>>2) | (0x02 << 6)
+	setVersion(u[:])
+	u[8] |= timeMarker
+
+	return generated(UUID(string(encodeBytes(u[:]))), KindTime)
+}
+
+// IsNil reports whether u means "no uuid": it is Nil or the all-zero uuid (00000000-0000-0000-0000-000000000000).
+// The two are deliberately treated the same, as FromString and Scan parse both into Nil and the all-zero uuid
+// only appears by casting, eg: UUID("00000000-0000-0000-0000-000000000000").
+func (u UUID) IsNil() bool {
+	return u == Nil || isZero(string(u))
+}
+
+// IsZero is the same as IsNil, it makes the omitzero json tag option omit Nil and the all-zero uuid.
+func (u UUID) IsZero() bool {
+	return u.IsNil()
+}
+
+// IsMax reports whether u is the Max UUID.
+func (u UUID) IsMax() bool {
+	return u == Max
+}
 
-	return UUID(string(encodeBytes(u[:])))
+// Version returns the version of the uuid (the 13th hex digit), eg: 4 for NewV4 and NewTime.
+// Returns an error for Nil and malformed uuids.
+func (u UUID) Version() (byte, error) {
+	b, err := u.decode()
+	if err != nil {
+		return 0, errInvalid("invalid uuid: " + u.String())
+	}
+
+	return b[6] >> 4, nil
 }
 
 func (u UUID) String() string {
@@ -117,13 +193,27 @@ func (u UUID) String() string {
 }
 
 // TimeUUIDToTime converts UUID into UTC time.
-// @warning - Handle with care.
-// If you use it for single UUID you will receive random/invalid timestamp.
+// It returns ErrNotTimeUUID for uuids without the time marker (see IsTimeUUID) if the check is enabled
+// with SetTimeMarkerCheck.
+// Returns ErrNil for Nil and an error for malformed uuids, only the layout of u is checked, see Validate.
 func (u UUID) TimeUUIDToTime() (time.Time, error) {
+	if u == Nil {
+		return time.Time{}.UTC(), ErrNil
+	}
+	if len(u) != 36 {
+		return time.Time{}.UTC(), errInvalid("invalid uuid, must be 36 characters long: " + strconv.Quote(u.String()))
+	}
+
 	tmp, err := hex.DecodeString(u.HashLike())
 	if err != nil {
 		return time.Time{}.UTC(), err
 	}
+	if len(tmp) != size {
+		return time.Time{}.UTC(), errInvalid("invalid uuid, must be hex digits in 8-4-4-4-12 groups: " + strconv.Quote(u.String()))
+	}
+	if timeMarkerCheck.Load() && !isTimeUUID([size]byte(tmp)) {
+		return time.Time{}.UTC(), ErrNotTimeUUID
+	}
 
 	ms := uint64(tmp[5]) | uint64(tmp[4])<<8 |
 		uint64(tmp[3])<<16 | uint64(tmp[2])<<24 |
@@ -132,11 +222,42 @@ func (u UUID) TimeUUIDToTime() (time.Time, error) {
 	return Time(ms), nil
 }
 
+// Timestamp returns the milliseconds elapsed since the unix epoch (1970-01-01T00:00:00Z), as used by NewTime.
+// Times before the epoch can not be represented in time uuids, so it panics for them like NewTime instead of
+// underflowing. Use TimestampE for times which are not known to be in range, eg: query bounds from user input.
 func Timestamp(t time.Time) uint64 {
+	if t.Before(unixEpoch) {
+		panic("time before unix epoch: " + t.Format(time.RFC3339Nano))
+	}
+
 	return uint64(t.Unix())*1000 +
 		uint64(t.Nanosecond()/int(time.Millisecond))
 }
 
+// TimestampE is like Timestamp, but returns an error instead of panicking for times which can not be stored
+// in a time uuid, see TimeInRange.
+func TimestampE(t time.Time) (uint64, error) {
+	if !TimeInRange(t) {
+		return 0, errors.New("time out of range for time uuids: " + t.Format(time.RFC3339Nano))
+	}
+
+	return Timestamp(t), nil
+}
+
+// MaxTime returns the time of MaxTimestamp, the latest time accepted by NewTime.
+func MaxTime() time.Time {
+	return Time(MaxTimestamp)
+}
+
+// TimeInRange reports whether t can be stored in a time uuid, meaning NewTime does not panic for it:
+// t is not before the unix epoch and not after MaxTime.
+func TimeInRange(t time.Time) bool {
+	// compare times, as Timestamp overflows for times far after MaxTime
+	return !t.Before(unixEpoch) && !t.After(MaxTime())
+}
+
+// Time is the reverse of Timestamp, it returns the UTC time ms milliseconds after the unix epoch,
+// so it never returns times before the epoch.
 func Time(ms uint64) time.Time {
 	s := int64(ms / 1e3)
 	ns := int64((ms % 1e3) * 1e6)
@@ -145,82 +266,220 @@ func Time(ms uint64) time.Time {
 
 // Next generates a new uuid from the current one. The uuid returned is consistent,
 // meaning calling Next() on a given uuid will always return the same value.
+// Max is a sentinel, not part of any chain, calling Next() on it returns an error.
+// Only the layout of u is checked, see Validate.
 func (u UUID) Next() (UUID, error) {
+	// add a big prime number (actually any odd number would work)
+	return u.shift(primeStep)
+}
+
+// NextSameTime is like Next, but keeps the first 6 bytes, so for time uuids (see NewTime) the result has the
+// same timestamp and sorts next to u. The step is added to the 64 bits in the 7th and the last 7 bytes only,
+// wrapping around on overflow, so the chain repeats after 2^64 steps.
+// Returns Nil for Nil and an error for Max and malformed uuids.
+func (u UUID) NextSameTime() (UUID, error) {
 	if u == Nil {
 		return Nil, nil
 	}
 
-	// remove dashes
-	hash := u.HashLike()
+	if u == Max {
+		return Nil, errors.New("max uuid is not part of any chain")
+	}
 
-	b, err := hex.DecodeString(hash)
+	b, err := u.decode()
 	if err != nil {
-		return Nil, errors.New("invalid uuid: " + u.String())
+		return Nil, errInvalid("invalid uuid: " + u.String())
 	}
 
-	// skip 6th and 8th byte as they contain version and variant bits
-	newB := appendAll(b[0:6], b[7:8], b[9:16])
+	// the low 64 bits of the prime are odd as well, so every value is visited once per 2^64 steps
+	n := uint64(b[7])<<56 | binary.BigEndian.Uint64(b[8:16])&(1<<56-1)
+	n += primeStep.Lo
 
-	// add a big prime number (actually any odd number would work)
-	bInt := new(big.Int)
-	bInt.SetBytes(newB)
-	bInt.Add(bInt, bigPrime)
+	b[7] = byte(n >> 56)
+	binary.BigEndian.PutUint64(b[8:16], uint64(b[8])<<56|n&(1<<56-1))
 
-	// len(newB) will never be less than 14 because bigPrime is 14 bytes long
-	newB = bInt.Bytes()
-	if len(newB) > 14 {
-		// cut overflow
-		newB = newB[len(newB)-14:]
+	return UUID(string(encodeBytes(b[:]))), nil
+}
+
+// NextN returns the n-th uuid of the chain started by u in a single step, the same as calling Next n times.
+// NextN(0) returns the uuid itself. Returns Nil for Nil and an error for Max and malformed uuids.
+func (u UUID) NextN(n uint64) (UUID, error) {
+	return u.shift(primeStep.Mul64(n))
+}
+
+// Prev is the inverse of Next: u.Next().Prev() and u.Prev().Next() both return u, wrapping around the same way.
+// Returns Nil for Nil and an error for Max and malformed uuids.
+func (u UUID) Prev() (UUID, error) {
+	// subtracting is adding the two's complement, the bits above 112 bits are cut anyway
+	return u.shift(Uint128{}.Sub(primeStep))
+}
+
+// Add adds delta to the 112 bits of the uuid which are not in the 6th and 8th byte (holding the version and
+// variant bits), the same way as Next, wrapping around on overflow. Add(0) returns the uuid itself.
+// Next adds a fixed 110 bits prime instead of 1, so Add(2) is not the same as calling Next twice.
+// Returns Nil for Nil and an error for Max and malformed uuids.
+func (u UUID) Add(delta uint64) (UUID, error) {
+	return u.shift(Uint128{Lo: delta})
+}
+
+// shift adds delta to the uuid, skipping the 6th and 8th byte as they contain version and variant bits.
+func (u UUID) shift(delta Uint128) (UUID, error) {
+	if u == Nil {
+		return Nil, nil
 	}
 
-	// add back bytes containing version and variant bits
-	newB = appendAll(newB[0:6], b[6:7], newB[6:7], b[8:9], newB[7:14])
+	if u == Max {
+		return Nil, errors.New("max uuid is not part of any chain")
+	}
 
-	return UUID(string(encodeBytes(newB))), nil
+	b, err := u.decode()
+	if err != nil {
+		return Nil, errInvalid("invalid uuid: " + u.String())
+	}
+
+	return fromWithoutVersion(withoutVersion(b).Add(delta), b), nil
 }
 
+// XOR calculates the bitwise XOR of two uuids, setting the version and variant bits of the result as in NewV4.
+// Returns Nil if any of the uuids is Nil and an error if any of them is Max.
+// Only the layout of the uuids is checked, see Validate.
 func (u UUID) XOR(v UUID) (UUID, error) {
 	if u == Nil || v == Nil {
 		return Nil, nil
 	}
 
-	// remove dashes
-	hash1 := u.HashLike()
-	hash2 := v.HashLike()
+	if u == Max || v == Max {
+		return Nil, errors.New("max uuid can not be xor-ed")
+	}
 
-	b1, err := hex.DecodeString(hash1)
+	b1, err := u.decode()
 	if err != nil {
-		return Nil, errors.New("invalid left side parameter: " + u.String())
+		return Nil, errInvalid("invalid left side parameter: " + u.String())
 	}
-	b2, err := hex.DecodeString(hash2)
+	b2, err := v.decode()
 	if err != nil {
-		return Nil, errors.New("invalid right side parameter: " + v.String())
+		return Nil, errInvalid("invalid right side parameter: " + v.String())
 	}
 
-	arr := make([]byte, 16)
-	for i := range b1 {
-		arr[i] = b1[i] ^ b2[i]
+	arr := uint128FromBytes(b1).Xor(uint128FromBytes(b2)).bytes()
+	setVersion(arr[:])
+
+	return UUID(string(encodeBytes(arr[:]))), nil
+}
+
+// XORStrict is like XOR, but returns an error instead of Nil if any of the uuids is Nil,
+// so a missing uuid can not silently turn the result into Nil. See XORAll for treating Nil as the identity.
+func (u UUID) XORStrict(v UUID) (UUID, error) {
+	if u == Nil {
+		return Nil, errors.New("missing left side parameter")
+	}
+	if v == Nil {
+		return Nil, errors.New("missing right side parameter")
 	}
 
-	// set version to v4
-	const v4 byte = 4
-	arr[6] = (arr[6] & 0x0f) | (v4 << 4)
-	// set variant to RFC4122
-	arr[8] = arr[8]&(0xff>>2) | (0x02 << 6)
+	return u.XOR(v)
+}
+
+// XORRaw calculates the bitwise XOR of all 128 bits of two uuids, without setting the version and variant bits,
+// so XORRaw(XORRaw(a, b), b) == a for any a and b, eg: for blinding. Nil is treated as the all-zero uuid,
+// which is also returned for equal uuids. The result is accepted by LenientFromString, but usually not by FromString.
+// Returns an error for malformed uuids.
+func XORRaw(a, b UUID) (UUID, error) {
+	var b1, b2 [size]byte
+	var err error
+
+	if a != Nil {
+		if b1, err = a.decode(); err != nil {
+			return Nil, errInvalid("invalid left side parameter: " + a.String())
+		}
+	}
+	if b != Nil {
+		if b2, err = b.decode(); err != nil {
+			return Nil, errInvalid("invalid right side parameter: " + b.String())
+		}
+	}
 
-	return UUID(string(encodeBytes(arr))), nil
+	return FromUint128(uint128FromBytes(b1).Xor(uint128FromBytes(b2)), false), nil
+}
+
+// XORAll calculates the bitwise XOR of all uuids in a single pass, setting the version and variant bits of
+// the result as in NewV4. The result does not depend on the order of the uuids.
+// Unlike XOR, Nil uuids are skipped, XORAll returns Nil only if there is nothing left to XOR.
+// Returns an error if any of the uuids is Max or malformed.
+func XORAll(uuids ...UUID) (UUID, error) {
+	var acc Uint128
+	found := false
+
+	for i, u := range uuids {
+		if u == Nil {
+			continue
+		}
+
+		if u == Max {
+			return Nil, errors.New("max uuid can not be xor-ed")
+		}
+
+		b, err := u.decode()
+		if err != nil {
+			return Nil, errInvalid("invalid uuid at index " + strconv.Itoa(i) + ": " + u.String())
+		}
+
+		acc = acc.Xor(uint128FromBytes(b))
+		found = true
+	}
+
+	if !found {
+		return Nil, nil
+	}
+
+	arr := acc.bytes()
+	setVersion(arr[:])
+
+	return UUID(string(encodeBytes(arr[:]))), nil
 }
 
 // HashLike returns the uuid without dashes, eg: afe406938f63476685f1250a427f1db5
+// Returns an empty string for Nil and malformed uuids (wrong length, dash positions or non-hex characters),
+// only the layout of u is checked, see Validate.
 func (u UUID) HashLike() string {
-	if u == Nil {
+	if u == Nil || !isHexLayout(string(u)) {
 		return ""
 	}
 
 	return string(u[0:8] + u[9:13] + u[14:18] + u[19:23] + u[24:])
 }
 
+// marshalValidation enables validation in MarshalText, MarshalJSON and MarshalBinary, see SetMarshalValidation.
+var marshalValidation atomic.Bool
+
+func init() {
+	marshalValidation.Store(true)
+}
+
+// SetMarshalValidation enables or disables validation of the uuid in MarshalText, MarshalJSON and MarshalBinary.
+// It is enabled by default, so uuids created by casting an invalid string fail when they are serialized
+// instead of when they are parsed by the consumer. Disable it to write uuids as is, eg: uuids with any version
+// and variant bits of LenientFromString.
+func SetMarshalValidation(enabled bool) {
+	marshalValidation.Store(enabled)
+}
+
+// validateMarshal returns the same error as Validate, unless disabled with SetMarshalValidation.
+func (u UUID) validateMarshal() error {
+	if !marshalValidation.Load() {
+		return nil
+	}
+
+	return u.Validate()
+}
+
+// MarshalText returns u as is. It returns the same error as Validate for malformed uuids, unless disabled with
+// SetMarshalValidation, so uuids of LenientFromString, FromTraceID or XORRaw are only written with it disabled.
 func (u UUID) MarshalText() ([]byte, error) {
+	if err := u.validateMarshal(); err != nil {
+		return nil, err
+	}
+
 	return []byte(u.String()), nil
 }
 
@@ -235,18 +494,25 @@ func (u *UUID) UnmarshalText(text []byte) error {
 	return nil
 }
 
+// MarshalJSON returns u as a json string as is. It returns the same error as MarshalText for malformed uuids.
 func (u UUID) MarshalJSON() ([]byte, error) {
+	if err := u.validateMarshal(); err != nil {
+		return nil, err
+	}
+
 	return []byte(strconv.Quote(u.String())), nil
 }
 
+// UnmarshalJSON parses a json string as in FromString, null results in Nil like Scan(nil).
 func (u *UUID) UnmarshalJSON(b []byte) error {
 	if bytes.Equal(b, []byte("null")) {
+		*u = Nil
 		return nil
 	}
 
 	str, err := strconv.Unquote(string(b))
 	if err != nil {
-		return errors.New("invalid json value for uuid (must be string or null): " + string(b))
+		return errInvalid("invalid json value for uuid (must be string or null): " + string(b))
 	}
 
 	uid, err := FromString(str)
@@ -267,17 +533,142 @@ func (u UUID) MarshalBinary() (data []byte, err error) {
 	return u.MarshalText()
 }
 
+// Bytes returns the 16 bytes of the uuid.
+// Returns the zero array with ErrNil for Nil, and an error for malformed uuids.
+func (u UUID) Bytes() ([size]byte, error) {
+	if u == Nil {
+		return [size]byte{}, ErrNil
+	}
+
+	b, err := u.decode()
+	if err != nil {
+		return [size]byte{}, errInvalid("invalid uuid: " + u.String())
+	}
+
+	return b, nil
+}
+
+// Value returns the 16 bytes of the uuid for database/sql, nil for Nil.
+// It returns the same error as Validate for invalid uuids, so they are not written to the database.
 func (u UUID) Value() (driver.Value, error) {
 	if u == Nil {
 		return nil, nil
 	}
 
-	if u[8] != '-' || u[13] != '-' || u[18] != '-' || u[23] != '-' {
-		return nil, fmt.Errorf("uuid: incorrect UUID format %s", u)
+	if err := u.Validate(); err != nil {
+		return nil, err
+	}
+
+	ba, err := u.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	// driver.Value support only slice, not array
+	return ba[:], nil
+}
+
+// Scan reads the 16 bytes written by Value, nil results in Nil.
+// Other values result in a *ScanError.
+func (u *UUID) Scan(src interface{}) error {
+	if src == nil {
+		*u = Nil
+		return nil
+	}
+
+	var data []byte
+	switch src := src.(type) {
+	case []byte:
+		data = src
+	case string:
+		// not supported, but the content helps to find the problem
+		return &ScanError{Type: "string", Length: len(src), Preview: scanPreview([]byte(src)), Unsupported: true}
+	default:
+		return &ScanError{Type: fmt.Sprintf("%T", src), Length: -1, Unsupported: true}
+	}
+
+	if len(data) != size {
+		return &ScanError{Type: "[]byte", Length: len(data), Preview: scanPreview(data)}
 	}
 
+	var err error
+	*u, err = FromString(string(encodeBytes(data)))
+	if err != nil {
+		return &ScanError{Type: "[]byte", Length: len(data), Preview: scanPreview(data), Err: err}
+	}
+
+	return nil
+}
+
+// ScanError is returned by Scan for values which can not be converted into a uuid, it wraps ErrInvalidUUID.
+type ScanError struct {
+	// Type is the type of the value, eg: []byte.
+	Type string
+	// Length is the length of []byte and string values, -1 for other types.
+	Length int
+	// Preview is the beginning of []byte and string values in hex and as an escaped string,
+	// eg: 0x61666534 "afe4"
+	Preview string
+	// Unsupported is true if the type of the value is not supported, otherwise the type is right,
+	// but the content is invalid.
+	Unsupported bool
+	// Err is the validation error of 16 bytes long values, eg: a *ParseError for invalid version bits.
+	Err error
+}
+
+func (e *ScanError) Error() string {
+	switch {
+	case e.Unsupported && e.Length < 0:
+		return "uuid: cannot scan " + e.Type + " into UUID, unsupported type"
+	case e.Unsupported:
+		return "uuid: cannot scan " + e.Type + " into UUID, unsupported type, length: " + strconv.Itoa(e.Length) +
+			", data: " + e.Preview
+	case e.Err != nil:
+		return "uuid: cannot scan " + e.Type + " into UUID: " + e.Err.Error()
+	default:
+		return "uuid: cannot scan " + e.Type + " into UUID, must be 16 bytes long, length: " + strconv.Itoa(e.Length) +
+			", data: " + e.Preview
+	}
+}
+
+func (e *ScanError) Unwrap() error {
+	if e.Err != nil {
+		return e.Err
+	}
+
+	return ErrInvalidUUID
+}
+
+// scanPreview returns the first 36 bytes of data (enough for a uuid in text format) in hex and as an escaped string,
+// safe to be logged.
+func scanPreview(data []byte) string {
+	const maxPreview = 36
+
+	n := min(len(data), maxPreview)
+	suffix := ""
+	if len(data) > n {
+		suffix = "..."
+	}
+
+	return "0x" + hex.EncodeToString(data[:n]) + suffix + " " + strconv.QuoteToASCII(string(data[:n])) + suffix
+}
+
+// setVersion sets the version to v4 and the variant to RFC4122.
+func setVersion(u []byte) {
+	const v4 byte = 4
+	u[6] = (u[6] & 0x0f) | (v4 << 4)
+	u[8] = u[8]&(0xff>>2) | (0x02 << 6)
+}
+
+// decode converts the canonical form into the 16 raw bytes, without validating version and variant bits.
+func (u UUID) decode() ([size]byte, error) {
 	// the backing array for the slice
 	var ba [size]byte
+
+	if len(u) != 36 || u[8] != '-' || u[13] != '-' || u[18] != '-' || u[23] != '-' {
+		return ba, errInvalid(fmt.Sprintf("uuid: incorrect UUID format %s", u))
+	}
+
 	src := []byte(u)
 	dst := ba[:]
 
@@ -287,37 +678,53 @@ func (u UUID) Value() (driver.Value, error) {
 		}
 		_, err := hex.Decode(dst[:byteGroup/2], src[:byteGroup])
 		if err != nil {
-			return nil, err
+			return ba, err
 		}
 		src = src[byteGroup:]
 		dst = dst[byteGroup/2:]
 	}
 
-	// driver.Value support only slice, not array
-	return ba[:], nil
+	return ba, nil
 }
 
-func (u *UUID) Scan(src interface{}) error {
-	if src == nil {
-		*u = Nil
-		return nil
-	}
-
-	if src, ok := src.([]byte); ok && len(src) == size {
-		buf := encodeBytes(src)
+// fromRaw converts the 16 raw bytes into a uuid, validated as in FromString.
+func fromRaw(b []byte) (UUID, error) {
+	return FromString(string(encodeBytes(b)))
+}
 
-		var err error
-		*u, err = FromString(string(buf))
+// withoutVersion returns the 112 bits of b without the 6th and 8th byte, which contain version and variant bits.
+func withoutVersion(b [size]byte) Uint128 {
+	var p [size]byte
+	copy(p[2:8], b[0:6])
+	p[8] = b[7]
+	copy(p[9:16], b[9:16])
 
-		return err
-	}
+	return uint128FromBytes(p)
+}
 
-	return fmt.Errorf("uuid: cannot convert %T to UUID", src)
+// fromWithoutVersion is the reverse of withoutVersion, taking the 6th and 8th byte from orig.
+// Bits of n above 112 bits are cut, so overflows wrap around.
+func fromWithoutVersion(n Uint128, orig [size]byte) UUID {
+	p := n.bytes()
+	var b [size]byte
+	copy(b[0:6], p[2:8])
+	b[6] = orig[6]
+	b[7] = p[8]
+	b[8] = orig[8]
+	copy(b[9:16], p[9:16])
+
+	return UUID(string(encodeBytes(b[:])))
 }
 
 func encodeBytes(u []byte) []byte {
 	buf := make([]byte, 36)
+	encodeInto(buf, u)
+
+	return buf
+}
 
+// encodeInto writes the canonical form of u into buf, which must be at least 36 bytes long.
+func encodeInto(buf []byte, u []byte) {
 	hex.Encode(buf[0:8], u[0:4])
 	buf[8] = '-'
 	hex.Encode(buf[9:13], u[4:6])
@@ -326,20 +733,5 @@ func encodeBytes(u []byte) []byte {
 	buf[18] = '-'
 	hex.Encode(buf[19:23], u[8:10])
 	buf[23] = '-'
-	hex.Encode(buf[24:], u[10:])
-
-	return buf
-}
-
-func appendAll(bs ...[]byte) []byte {
-	l := 0
-	for _, b := range bs {
-		l += len(b)
-	}
-	res := make([]byte, 0, l)
-	for _, b := range bs {
-		res = append(res, b...)
-	}
-
-	return res
+	hex.Encode(buf[24:36], u[10:16])
 }
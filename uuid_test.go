@@ -1,8 +1,14 @@
 package uuid
 
 import (
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"math"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/ugorji/go/codec"
@@ -13,6 +19,8 @@ var tests = map[string]string{
 	"00000000-0000-0000-0000-000000000000": "",
 	"afe40693-8f63-4766-85f1-250a427f1db5": "afe40693-8f63-4766-85f1-250a427f1db5",
 	"AFE40693-8F63-4766-85F1-250a427F1DB5": "afe40693-8f63-4766-85f1-250a427f1db5",
+	"ffffffff-ffff-ffff-ffff-ffffffffffff": "ffffffff-ffff-ffff-ffff-ffffffffffff",
+	"FFFFFFFF-FFFF-FFFF-FFFF-FFFFFFFFFFFF": "ffffffff-ffff-ffff-ffff-ffffffffffff",
 }
 
 var testErrors = []string{
@@ -50,6 +58,126 @@ func TestFromStringError(t *testing.T) {
 		if err == nil {
 			t.Errorf("expected error, but got nothing for %v", orig)
 		}
+		if !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("want: %v, got: %v", ErrInvalidUUID, err)
+		}
+	}
+}
+
+func TestErrInvalidUUID(t *testing.T) {
+	for name, parse := range map[string]func(string) (UUID, error){
+		"ParseAny":           ParseAny,
+		"FromURN":            FromURN,
+		"FromBraced":         FromBraced,
+		"LenientFromString":  LenientFromString,
+		"FromStringV4":       FromStringV4,
+		"FromShort":          FromShort,
+		"FromBase58":         FromBase58,
+		"FromSortableString": FromSortableString,
+		"ParseBytes":         func(str string) (UUID, error) { return ParseBytes([]byte(str)) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			for _, orig := range []string{"asda", "{asda}", "urn:uuid:asda"} {
+				if _, err := parse(orig); !errors.Is(err, ErrInvalidUUID) {
+					t.Errorf("want: %v, got: %v for %v", ErrInvalidUUID, err, orig)
+				}
+			}
+		})
+	}
+
+	for name, u := range map[string]interface {
+		UnmarshalJSON([]byte) error
+	}{
+		"FlexibleUUID": new(FlexibleUUID),
+		"NullableUUID": new(NullableUUID),
+		"HashUUID":     new(HashUUID),
+		"StrictUUID":   new(StrictUUID),
+	} {
+		for _, orig := range []string{`"asda"`, `1`} {
+			if err := u.UnmarshalJSON([]byte(orig)); !errors.Is(err, ErrInvalidUUID) {
+				t.Errorf("want: %v, got: %v for %v %v", ErrInvalidUUID, err, name, orig)
+			}
+		}
+	}
+
+	// the message starts as before
+	if _, err := FromString("asda"); !strings.HasPrefix(err.Error(), "invalid uuid: asda") {
+		t.Errorf("want: %v, got: %v", "invalid uuid: asda", err)
+	}
+}
+
+func TestParseError(t *testing.T) {
+	for _, data := range []struct {
+		original string
+		hash     bool
+		offset   int
+		reason   ParseReason
+		msg      string
+	}{
+		{original: "asda", offset: 4, reason: ReasonLength, msg: "invalid uuid: asda (wrong length: 4)"},
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5a", offset: 36, reason: ReasonLength},
+		{original: "gfe40693-8f63-4766-85f1-250a427f1db5", offset: 0, reason: ReasonCharacter},
+		{original: "afe40693-8f63-4766-85f1-250a427f1dbz", offset: 35, reason: ReasonCharacter},
+		{original: "afe40693+8f63-4766-85f1-250a427f1db5", offset: 8, reason: ReasonCharacter},
+		{original: "afe406938-f63-4766-85f1-250a427f1db5", offset: 8, reason: ReasonCharacter},
+		{
+			original: "99999999-9999-6999-9999-250a427f1db5",
+			offset:   14,
+			reason:   ReasonVersion,
+			msg:      "invalid uuid: 99999999-9999-6999-9999-250a427f1db5 (invalid version at offset 14)",
+		},
+		{original: "99999999-9999-0999-9999-250a427f1db5", offset: 14, reason: ReasonVersion},
+		{original: "99999999-9999-4999-1999-250a427f1db5", offset: 19, reason: ReasonVariant},
+		{original: "99999999-9999-4999-c999-250a427f1db5", offset: 19, reason: ReasonVariant},
+		// the first problem from left to right
+		{original: "99999999-9999-6999-1999-250a427f1dbz", offset: 14, reason: ReasonVersion},
+		{original: "afe406938f63476685f1250a427f1db", hash: true, offset: 31, reason: ReasonLength},
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", hash: true, offset: 32, reason: ReasonLength},
+		{original: "afe40693-8f63476685f1250a427f1db", hash: true, offset: 8, reason: ReasonCharacter},
+		{original: "99999999999969999999250a427f1db5", hash: true, offset: 12, reason: ReasonVersion},
+		{original: "99999999999949991999250a427f1db5", hash: true, offset: 16, reason: ReasonVariant},
+	} {
+		var err error
+		if data.hash {
+			_, err = FromHashLike(data.original)
+		} else {
+			_, err = FromString(data.original)
+		}
+
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("want *ParseError, got: %v for %v", err, data.original)
+		}
+		want := ParseError{Input: data.original, Offset: data.offset, Reason: data.reason}
+		if *parseErr != want {
+			t.Errorf("want: %+v, got: %+v", want, *parseErr)
+		}
+		if data.msg != "" && err.Error() != data.msg {
+			t.Errorf("want: %v, got: %v", data.msg, err)
+		}
+		if !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("want: %v, got: %v", ErrInvalidUUID, err)
+		}
+	}
+}
+
+// TestParseMatchesRegex checks that the validator accepts exactly what the former regexp accepted.
+func TestParseMatchesRegex(t *testing.T) {
+	re := regexp.MustCompile(`(?i)^[0-9a-f]{8}-[0-9a-f]{4}-[1-5][0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+	const valid = "afe40693-8f63-4766-85f1-250a427f1db5"
+	chars := "0123456789abcdefABCDEFgG-x \n\x00\xff"
+	for i := 0; i < len(valid); i++ {
+		for j := 0; j < len(chars); j++ {
+			str := valid[:i] + chars[j:j+1] + valid[i+1:]
+			for _, str := range []string{str, str + "\n", str[:35]} {
+				_, err := FromString(str)
+				want := re.MatchString(str)
+				if (err == nil) != want {
+					t.Errorf("want valid: %v, got: %v for %q", want, err, str)
+				}
+			}
+		}
 	}
 }
 
@@ -100,6 +228,111 @@ func TestJSONError(t *testing.T) {
 		if err == nil {
 			t.Errorf("expected error, but got nothing for %v", orig)
 		}
+		if !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("want: %v, got: %v", ErrInvalidUUID, err)
+		}
+
+		err = uid.UnmarshalText([]byte(orig))
+		if !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("want: %v, got: %v", ErrInvalidUUID, err)
+		}
+	}
+
+	var uid UUID
+	if err := json.Unmarshal([]byte(`1`), &uid); !errors.Is(err, ErrInvalidUUID) {
+		t.Errorf("want: %v, got: %v", ErrInvalidUUID, err)
+	}
+}
+
+func TestMarshalValidation(t *testing.T) {
+	for _, orig := range testErrors {
+		t.Run(orig, func(t *testing.T) {
+			u := UUID(orig)
+
+			if _, err := u.MarshalText(); err == nil {
+				t.Errorf("MarshalText expected error, but got nothing for %v", orig)
+			}
+			if _, err := u.MarshalBinary(); err == nil {
+				t.Errorf("MarshalBinary expected error, but got nothing for %v", orig)
+			}
+			if _, err := json.Marshal(u); err == nil {
+				t.Errorf("json.Marshal expected error, but got nothing for %v", orig)
+			}
+		})
+	}
+
+	for orig := range tests {
+		if _, err := json.Marshal(UUID(orig)); err != nil {
+			t.Errorf("unexpected error for %q: %v", orig, err)
+		}
+	}
+}
+
+func TestMarshalValidationLenient(t *testing.T) {
+	lenient, err := LenientFromString("99999999-9999-6999-1999-250a427f1db5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	xor, err := XORRaw("afe40693-8f63-4766-85f1-250a427f1db5", "43ae2f25-802d-4aae-be57-b7acefe336ac")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ranges, err := SplitRange(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uuids := []UUID{
+		lenient,
+		xor,
+		FromTraceID([16]byte{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x6d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}),
+		FromUint64PairLenient(1, 2),
+		ranges[1].First,
+		ranges[1].Last,
+	}
+
+	// rejected like by the consumer
+	for _, u := range uuids {
+		if _, err := json.Marshal(u); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("want: %v, got: %v for %v", ErrInvalidUUID, err, u)
+		}
+		if _, err := HashUUID(u).MarshalText(); !errors.Is(err, ErrInvalidUUID) {
+			t.Errorf("want: %v, got: %v for %v", ErrInvalidUUID, err, u)
+		}
+	}
+
+	SetMarshalValidation(false)
+	defer SetMarshalValidation(true)
+
+	for _, u := range uuids {
+		b, err := json.Marshal(u)
+		if err != nil {
+			t.Fatalf("unexpected error for %v: %v", u, err)
+		}
+		if want := `"` + u.String() + `"`; string(b) != want {
+			t.Errorf("want: %s, got: %s", want, b)
+		}
+	}
+}
+
+func TestMarshalValidationDisabled(t *testing.T) {
+	SetMarshalValidation(false)
+	defer SetMarshalValidation(true)
+
+	b, err := json.Marshal(UUID("asda"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `"asda"` {
+		t.Errorf("want: %s, got: %s", `"asda"`, b)
+	}
+
+	text, err := UUID("asda").MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(text) != "asda" {
+		t.Errorf("want: asda, got: %s", text)
 	}
 }
 
@@ -193,6 +426,31 @@ func TestSql(t *testing.T) {
 	}
 }
 
+func TestBytes(t *testing.T) {
+	b, err := UUID("afe40693-8f63-4766-85f1-250a427f1db5").Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [16]byte{0xaf, 0xe4, 0x06, 0x93, 0x8f, 0x63, 0x47, 0x66, 0x85, 0xf1, 0x25, 0x0a, 0x42, 0x7f, 0x1d, 0xb5}
+	if b != want {
+		t.Errorf("want: %x, got: %x", want, b)
+	}
+
+	if b, err := Nil.Bytes(); err != ErrNil || b != [16]byte{} {
+		t.Errorf("want zero bytes and ErrNil for Nil, got: %x, %v", b, err)
+	}
+
+	for _, u := range []UUID{"asda", "afe40693-8f63-4766-85f1-250a427f1dbx", "afe40693x8f63-4766-85f1-250a427f1db5"} {
+		b, err := u.Bytes()
+		if err == nil {
+			t.Errorf("expected error, but got nothing for %v", u)
+		}
+		if b != [16]byte{} {
+			t.Errorf("want zero bytes for %v, got: %x", u, b)
+		}
+	}
+}
+
 func TestSqlError(t *testing.T) {
 	for _, orig := range testErrors {
 		t.Run(orig, func(t *testing.T) {
@@ -201,10 +459,250 @@ func TestSqlError(t *testing.T) {
 			if err == nil {
 				t.Fatalf("expected error, but got nothing for %v", orig)
 			}
+			if !errors.Is(err, ErrInvalidUUID) {
+				t.Errorf("want: %v, got: %v", ErrInvalidUUID, err)
+			}
+		})
+	}
+}
+
+func TestScanError(t *testing.T) {
+	for _, data := range []struct {
+		name        string
+		src         interface{}
+		want        ScanError
+		wantParse   bool
+		wantMessage string
+	}{
+		{
+			name:        "unsupported type",
+			src:         42,
+			want:        ScanError{Type: "int", Length: -1, Unsupported: true},
+			wantMessage: "uuid: cannot scan int into UUID, unsupported type",
+		},
+		{
+			name: "text in string",
+			src:  "afe40693-8f63-4766-85f1-250a427f1db5",
+			want: ScanError{
+				Type:        "string",
+				Length:      36,
+				Preview:     "0x61666534303639332d386636332d343736362d383566312d323530613432376631646235 \"afe40693-8f63-4766-85f1-250a427f1db5\"",
+				Unsupported: true,
+			},
+		},
+		{
+			name: "text in bytes",
+			src:  []byte("afe40693-8f63-4766-85f1-250a427f1db5"),
+			want: ScanError{
+				Type:    "[]byte",
+				Length:  36,
+				Preview: "0x61666534303639332d386636332d343736362d383566312d323530613432376631646235 \"afe40693-8f63-4766-85f1-250a427f1db5\"",
+			},
+			wantMessage: "uuid: cannot scan []byte into UUID, must be 16 bytes long, length: 36, data: " +
+				"0x61666534303639332d386636332d343736362d383566312d323530613432376631646235 \"afe40693-8f63-4766-85f1-250a427f1db5\"",
+		},
+		{
+			name: "17 bytes",
+			src:  []byte("\xaf\xe4\x06\x93\x8f\x63\x47\x66\x85\xf1\x25\x0a\x42\x7f\x1d\xb5\x00"),
+			want: ScanError{
+				Type:    "[]byte",
+				Length:  17,
+				Preview: "0xafe406938f63476685f1250a427f1db500 \"\\xaf\\xe4\\x06\\x93\\x8fcGf\\x85\\xf1%\\nB\\x7f\\x1d\\xb5\\x00\"",
+			},
+		},
+		{
+			name: "long",
+			src:  make([]byte, 40),
+			want: ScanError{
+				Type:    "[]byte",
+				Length:  40,
+				Preview: "0x" + strings.Repeat("00", 36) + "... \"" + strings.Repeat("\\x00", 36) + "\"...",
+			},
+		},
+		{
+			name: "empty",
+			src:  []byte{},
+			want: ScanError{Type: "[]byte", Length: 0, Preview: `0x ""`},
+		},
+		{
+			name:      "invalid version",
+			src:       []byte("\x99\x99\x99\x99\x99\x99\x69\x99\x99\x99\x25\x0a\x42\x7f\x1d\xb5"),
+			want:      ScanError{Type: "[]byte", Length: 16, Preview: "0x99999999999969999999250a427f1db5 \"\\x99\\x99\\x99\\x99\\x99\\x99i\\x99\\x99\\x99%\\nB\\x7f\\x1d\\xb5\""},
+			wantParse: true,
+			wantMessage: "uuid: cannot scan []byte into UUID: " +
+				"invalid uuid: 99999999-9999-6999-9999-250a427f1db5 (invalid version at offset 14)",
+		},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			var u UUID
+			err := u.Scan(data.src)
+
+			var scanErr *ScanError
+			if !errors.As(err, &scanErr) {
+				t.Fatalf("want *ScanError, got: %v", err)
+			}
+
+			got := *scanErr
+			var parseErr *ParseError
+			if errors.As(got.Err, &parseErr) != data.wantParse {
+				t.Errorf("want parse error: %v, got: %v", data.wantParse, got.Err)
+			}
+			got.Err = nil
+			if got != data.want {
+				t.Errorf("want: %+v, got: %+v", data.want, got)
+			}
+
+			if data.wantMessage != "" && err.Error() != data.wantMessage {
+				t.Errorf("want: %v, got: %v", data.wantMessage, err)
+			}
+			if !errors.Is(err, ErrInvalidUUID) {
+				t.Errorf("want: %v, got: %v", ErrInvalidUUID, err)
+			}
+		})
+	}
+}
+
+func TestSqlValueError(t *testing.T) {
+	for _, orig := range testErrors {
+		t.Run(orig, func(t *testing.T) {
+			driverValue, err := UUID(orig).Value()
+			if err == nil {
+				t.Fatalf("expected error, but got nothing for %v", orig)
+			}
+			if !errors.Is(err, ErrInvalidUUID) {
+				t.Errorf("want: %v, got: %v", ErrInvalidUUID, err)
+			}
+			if driverValue != nil {
+				t.Errorf("want nil value, got: %v", driverValue)
+			}
+
+			// the same bits are rejected when they are read back
+			if !isHexLayout(orig) {
+				return
+			}
+			b, err := hex.DecodeString(strings.ReplaceAll(orig, "-", ""))
+			if err != nil {
+				return
+			}
+
+			var scanValue UUID
+			if err := scanValue.Scan(b); !errors.Is(err, ErrInvalidUUID) {
+				t.Errorf("want: %v, got: %v", ErrInvalidUUID, err)
+			}
 		})
 	}
 }
 
+func TestUnmarshalJSONNullResets(t *testing.T) {
+	var u UUID
+	if err := json.Unmarshal([]byte(`"afe40693-8f63-4766-85f1-250a427f1db5"`), &u); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`null`), &u); err != nil {
+		t.Fatal(err)
+	}
+	if u != Nil {
+		t.Errorf("want: %v, got: %v", Nil, u)
+	}
+
+	// reused structs, eg: elements of a slice
+	type record struct {
+		ID UUID `json:"id"`
+	}
+	records := []record{{ID: NewV4()}, {ID: NewV4()}}
+	if err := json.Unmarshal([]byte(`[{"id":null},{"id":"afe40693-8f63-4766-85f1-250a427f1db5"}]`), &records); err != nil {
+		t.Fatal(err)
+	}
+	if records[0].ID != Nil || records[1].ID != "afe40693-8f63-4766-85f1-250a427f1db5" {
+		t.Errorf("want: %v, %v, got: %v", Nil, "afe40693-8f63-4766-85f1-250a427f1db5", records)
+	}
+}
+
+func TestIsZero(t *testing.T) {
+	for _, data := range []struct {
+		original UUID
+		want     bool
+	}{
+		{original: Nil, want: true},
+		{original: "00000000-0000-0000-0000-000000000000", want: true},
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", want: false},
+		{original: Max, want: false},
+	} {
+		if got := data.original.IsZero(); got != data.want {
+			t.Errorf("want: %v, got: %v for %v", data.want, got, data.original)
+		}
+	}
+}
+
+func TestOmitZero(t *testing.T) {
+	// omitzero is supported by encoding/json since go 1.24
+	if b, _ := json.Marshal(struct {
+		T time.Time `json:"t,omitzero"`
+	}{}); string(b) != `{}` {
+		t.Skip("omitzero is not supported")
+	}
+
+	type record struct {
+		ID UUID `json:"id,omitzero"`
+	}
+
+	for _, data := range []struct {
+		original UUID
+		want     string
+	}{
+		{original: Nil, want: `{}`},
+		{original: "00000000-0000-0000-0000-000000000000", want: `{}`},
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", want: `{"id":"afe40693-8f63-4766-85f1-250a427f1db5"}`},
+	} {
+		b, err := json.Marshal(record{ID: data.original})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data.want != string(b) {
+			t.Errorf("want: %s, got: %s", data.want, b)
+		}
+	}
+}
+
+func TestVersion(t *testing.T) {
+	generated := []UUID{NewV4(), NewTime(Time(0)), NewTime(Time(281474976710655))}
+
+	for _, u := range generated {
+		version, err := u.Version()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if version != uuid.V4 {
+			t.Errorf("invalid version of %v, want: %v, got: %v", u, uuid.V4, version)
+		}
+	}
+
+	for orig, want := range tests {
+		if want == "" {
+			continue
+		}
+
+		uid, err := uuid.FromString(want)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		version, err := UUID(want).Version()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if version != uid.Version() {
+			t.Errorf("invalid version of %v, want: %v, got: %v", orig, uid.Version(), version)
+		}
+	}
+
+	for _, u := range []UUID{Nil, "asda", "afe40693x8f63-4766-85f1-250a427f1db5"} {
+		if _, err := u.Version(); err == nil {
+			t.Errorf("expected error, but got nothing for %v", u)
+		}
+	}
+}
+
 func TestNewV4(t *testing.T) {
 	const max = 100000
 
@@ -263,48 +761,188 @@ func TestNewTimeUUID(t *testing.T) {
 	}
 }
 
-func TestFromHashLike(t *testing.T) {
+func TestTimestampEpoch(t *testing.T) {
 	for _, data := range []struct {
-		original string
-		want     UUID
+		original time.Time
+		want     uint64
 	}{
-		{
-			original: "",
-			want:     "",
-		},
-		{
-			original: "00000000000000000000000000000000",
-			want:     "",
-		},
-		{
-			original: "afe406938f63476685f1250a427f1db5",
-			want:     "afe40693-8f63-4766-85f1-250a427f1db5",
-		},
-		{
-			original: "AFE406938F63476685F1250a427F1DB5",
-			want:     "afe40693-8f63-4766-85f1-250a427f1db5",
-		},
+		{original: time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC), want: 0},
+		{original: time.Date(1970, 1, 1, 0, 0, 0, 1e6, time.UTC), want: 1},
+		{original: time.Date(1970, 1, 1, 1, 0, 0, 0, time.FixedZone("CET", 3600)), want: 0},
 	} {
-		got, err := FromHashLike(data.original)
+		if got := Timestamp(data.original); got != data.want {
+			t.Errorf("want: %v, got: %v", data.want, got)
+		}
+		if got := Time(data.want); !got.Equal(data.original) {
+			t.Errorf("want: %v, got: %v", data.original, got)
+		}
+
+		revertedTime, err := NewTime(data.original).TimeUUIDToTime()
 		if err != nil {
 			t.Fatal(err)
 		}
-
-		if data.want != got {
-			t.Errorf("want: %s, got: %s", data.want, got)
+		if !revertedTime.Equal(data.original) {
+			t.Errorf("want: %v, got: %v", data.original, revertedTime)
 		}
 	}
 }
 
-func TestFromHashLikeError(t *testing.T) {
+func TestMaxTimestamp(t *testing.T) {
+	max := MaxTime()
+	if want := time.Date(10889, 8, 2, 5, 31, 50, 655e6, time.UTC); !max.Equal(want) {
+		t.Errorf("want: %v, got: %v", want, max)
+	}
+	if got := Timestamp(max); got != MaxTimestamp {
+		t.Errorf("want: %v, got: %v", MaxTimestamp, got)
+	}
+
+	// the boundary is accepted by NewTime
+	if !TimeInRange(max) {
+		t.Errorf("want in range: %v", max)
+	}
+	revertedTime, err := NewTime(max).TimeUUIDToTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !revertedTime.Equal(max) {
+		t.Errorf("want: %v, got: %v", max, revertedTime)
+	}
+
+	// the next millisecond is not
+	after := max.Add(time.Millisecond)
+	if TimeInRange(after) {
+		t.Errorf("want out of range: %v", after)
+	}
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("expected panic, but got nothing for %v", after)
+			}
+		}()
+
+		NewTime(after)
+	}()
+
 	for _, data := range []struct {
-		name     string
-		original string
+		original time.Time
+		want     bool
 	}{
-		{
-			name:     "too short",
-			original: "asda",
-		},
+		{original: time.Unix(0, 0), want: true},
+		{original: time.Now(), want: true},
+		{original: time.Unix(0, 0).Add(-time.Millisecond), want: false},
+		{original: time.Unix(0, 0).Add(-time.Nanosecond), want: false},
+		{original: time.Time{}, want: false},
+		// Timestamp would overflow
+		{original: time.Unix(1<<62, 0), want: false},
+	} {
+		if got := TimeInRange(data.original); got != data.want {
+			t.Errorf("want: %v, got: %v for %v", data.want, got, data.original)
+		}
+	}
+}
+
+func TestTimestampBeforeEpoch(t *testing.T) {
+	for name, fn := range map[string]func(time.Time){
+		"Timestamp": func(tm time.Time) { Timestamp(tm) },
+		"NewTime":   func(tm time.Time) { NewTime(tm) },
+	} {
+		for _, tm := range []time.Time{
+			time.Date(1969, 12, 31, 23, 59, 59, 999e6, time.UTC),
+			time.Date(1969, 12, 31, 23, 59, 59, 999999999, time.UTC),
+			time.Date(1965, 3, 1, 0, 0, 0, 0, time.UTC),
+		} {
+			t.Run(name+" "+tm.String(), func(t *testing.T) {
+				if _, err := TimestampE(tm); err == nil {
+					t.Errorf("expected error, but got nothing for %v", tm)
+				}
+
+				defer func() {
+					r := recover()
+					if r == nil {
+						t.Fatal("expected panic, but got nothing")
+					}
+
+					msg, ok := r.(string)
+					if !ok || !strings.Contains(msg, "before unix epoch") {
+						t.Errorf("unexpected panic: %v", r)
+					}
+				}()
+
+				fn(tm)
+			})
+		}
+	}
+}
+
+func TestTimestampE(t *testing.T) {
+	for _, data := range []struct {
+		original time.Time
+		want     uint64
+	}{
+		{original: time.Unix(0, 0), want: 0},
+		{original: time.Unix(0, int64(time.Millisecond)), want: 1},
+		{original: MaxTime(), want: MaxTimestamp},
+	} {
+		got, err := TimestampE(data.original)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != data.want {
+			t.Errorf("want: %v, got: %v", data.want, got)
+		}
+	}
+
+	if _, err := TimestampE(MaxTime().Add(time.Millisecond)); err == nil {
+		t.Error("expected error, but got nothing for time after MaxTime")
+	}
+}
+
+func TestFromHashLike(t *testing.T) {
+	for _, data := range []struct {
+		original string
+		want     UUID
+	}{
+		{
+			original: "",
+			want:     "",
+		},
+		{
+			original: "00000000000000000000000000000000",
+			want:     "",
+		},
+		{
+			original: "afe406938f63476685f1250a427f1db5",
+			want:     "afe40693-8f63-4766-85f1-250a427f1db5",
+		},
+		{
+			original: "AFE406938F63476685F1250a427F1DB5",
+			want:     "afe40693-8f63-4766-85f1-250a427f1db5",
+		},
+		{
+			original: "FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF",
+			want:     Max,
+		},
+	} {
+		got, err := FromHashLike(data.original)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if data.want != got {
+			t.Errorf("want: %s, got: %s", data.want, got)
+		}
+	}
+}
+
+func TestFromHashLikeError(t *testing.T) {
+	for _, data := range []struct {
+		name     string
+		original string
+	}{
+		{
+			name:     "too short",
+			original: "asda",
+		},
 		{
 			name:     "too long",
 			original: "afe406938f63476685f1250a427f1db51",
@@ -331,6 +969,9 @@ func TestFromHashLikeError(t *testing.T) {
 			if err == nil {
 				t.Errorf("expected error, but got nothing for %v", data.original)
 			}
+			if !errors.Is(err, ErrInvalidUUID) {
+				t.Errorf("want: %v, got: %v", ErrInvalidUUID, err)
+			}
 		})
 	}
 }
@@ -348,6 +989,18 @@ func TestHashLike(t *testing.T) {
 			original: "afe40693-8f63-4766-85f1-250a427f1db5",
 			want:     "afe406938f63476685f1250a427f1db5",
 		},
+		{
+			original: "abc",
+			want:     "",
+		},
+		{
+			original: "afe406938f63476685f1250a427f1db5abcd",
+			want:     "",
+		},
+		{
+			original: "afe40693-8f63-4766-85f1-250a427f1dbx",
+			want:     "",
+		},
 	} {
 		got := data.original.HashLike()
 
@@ -357,6 +1010,45 @@ func TestHashLike(t *testing.T) {
 	}
 }
 
+func TestMalformedCast(t *testing.T) {
+	valid := UUID("afe40693-8f63-4766-85f1-250a427f1db5")
+
+	for _, data := range []struct {
+		name     string
+		original UUID
+	}{
+		{name: "short", original: "abc"},
+		{name: "one character", original: "a"},
+		{name: "36 characters without dashes", original: "afe406938f63476685f1250a427f1db5abcd"},
+		{name: "non-hex", original: "afe40693-8f63-4766-85f1-250a427f1dbx"},
+		{name: "misplaced dashes", original: "afe4069-38f63-4766-85f1-250a427f1db5"},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			if got := data.original.HashLike(); got != "" {
+				t.Errorf("HashLike want empty string, got: %v", got)
+			}
+			if got := data.original.UpperHashLike(); got != "" {
+				t.Errorf("UpperHashLike want empty string, got: %v", got)
+			}
+			if _, err := data.original.Next(); err == nil {
+				t.Error("Next expected error, but got nothing")
+			}
+			if _, err := data.original.XOR(valid); err == nil {
+				t.Error("XOR expected error, but got nothing")
+			}
+			if _, err := valid.XOR(data.original); err == nil {
+				t.Error("XOR expected error, but got nothing")
+			}
+			if _, err := data.original.Value(); err == nil {
+				t.Error("Value expected error, but got nothing")
+			}
+			if _, err := data.original.TimeUUIDToTime(); err == nil {
+				t.Error("TimeUUIDToTime expected error, but got nothing")
+			}
+		})
+	}
+}
+
 func TestNext(t *testing.T) {
 	count := 10000
 	m := make(map[UUID]struct{}, count)
@@ -397,6 +1089,277 @@ func TestNextConsistent(t *testing.T) {
 	}
 }
 
+func TestNextValues(t *testing.T) {
+	for _, data := range []struct {
+		original UUID
+		want     []UUID
+	}{
+		{
+			original: "afe40693-8f63-4766-85f1-250a427f1db5",
+			want:     []UUID{"dca97cf2-e0f5-477f-8529-4c98ed7564ce", "096ef352-3287-4797-8561-7427986babe7"},
+		},
+		{
+			// overflow wraps around
+			original: "ffffffff-ffff-4fff-bfff-ffffffffffff",
+			want:     []UUID{"2cc5765f-5192-4f18-bf38-278eaaf64718", "598aecbe-a324-4f30-bf70-4f1d55ec8e31"},
+		},
+		{
+			original: "00000000-0000-4000-8000-000000000001",
+			want:     []UUID{"2cc5765f-5192-4018-8038-278eaaf6471a", "598aecbe-a324-4030-8070-4f1d55ec8e33"},
+		},
+	} {
+		uid := data.original
+		for _, want := range data.want {
+			var err error
+			uid, err = uid.Next()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if want != uid {
+				t.Errorf("want: %v, got: %v", want, uid)
+			}
+		}
+	}
+}
+
+func TestNextSameTime(t *testing.T) {
+	for _, data := range []struct {
+		original UUID
+		want     UUID
+	}{
+		{original: "016d6c41-26bb-4766-85f1-250a427f1db5", want: "016d6c41-26bb-477f-8529-4c98ed7564ce"},
+		// overflow wraps around
+		{original: "016d6c41-26bb-4fff-85ff-ffffffffffff", want: "016d6c41-26bb-4f18-8538-278eaaf64718"},
+	} {
+		got, err := data.original.NextSameTime()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != data.want {
+			t.Errorf("want: %v, got: %v", data.want, got)
+		}
+	}
+
+	if got, err := Nil.NextSameTime(); err != nil || got != Nil {
+		t.Errorf("want Nil for Nil, got: %v, %v", got, err)
+	}
+
+	for _, orig := range []UUID{Max, "asda"} {
+		if _, err := orig.NextSameTime(); err == nil {
+			t.Errorf("expected error, but got nothing for %v", orig)
+		}
+	}
+}
+
+func TestNextSameTimeChain(t *testing.T) {
+	const max = 100000
+
+	parent := NewTime(time.Now())
+	parentTime, err := parent.TimeUUIDToTime()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uuids := make(map[UUID]struct{}, max)
+	uid := parent
+	for i := 0; i < max; i++ {
+		uid, err = uid.NextSameTime()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, ok := uuids[uid]; ok {
+			t.Fatalf("NextSameTime returned same uuid twice: %s", uid)
+		}
+		uuids[uid] = struct{}{}
+
+		if _, err := FromString(uid.String()); err != nil {
+			t.Fatal(err)
+		}
+
+		childTime, err := uid.TimeUUIDToTime()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !childTime.Equal(parentTime) {
+			t.Fatalf("time of %v is different from its parent %v: %v != %v", uid, parent, childTime, parentTime)
+		}
+	}
+}
+
+func TestNextN(t *testing.T) {
+	for _, original := range []UUID{
+		"afe40693-8f63-4766-85f1-250a427f1db5",
+		"ffffffff-ffff-4fff-bfff-ffffffffffff",
+		"00000000-0000-4000-8000-000000000001",
+	} {
+		// the chain wraps around the 112 bits space several times in the first 100000 steps
+		want := original
+		for k := uint64(0); k <= 100000; k++ {
+			if k%9973 == 0 || k < 10 {
+				got, err := original.NextN(k)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != want {
+					t.Fatalf("%v.NextN(%v) want: %v, got: %v", original, k, want, got)
+				}
+			}
+
+			var err error
+			if want, err = want.Next(); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	if got, err := Nil.NextN(5); err != nil || got != Nil {
+		t.Errorf("want Nil for Nil, got: %v, %v", got, err)
+	}
+
+	for _, orig := range []UUID{Max, "asda"} {
+		if _, err := orig.NextN(1); err == nil {
+			t.Errorf("expected error, but got nothing for %v", orig)
+		}
+	}
+}
+
+func TestNextNLarge(t *testing.T) {
+	u := UUID("afe40693-8f63-4766-85f1-250a427f1db5")
+
+	// (n + 1) steps are the same as n steps followed by Next
+	for _, n := range []uint64{1 << 40, 1<<63 + 12345, math.MaxUint64 - 1} {
+		a, err := u.NextN(n + 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		b, err := u.NextN(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b, err = b.Next(); err != nil {
+			t.Fatal(err)
+		}
+
+		if a != b {
+			t.Errorf("NextN(%v) is different from NextN(%v).Next(): %v != %v", n+1, n, a, b)
+		}
+	}
+}
+
+func TestPrev(t *testing.T) {
+	for _, original := range []UUID{
+		"afe40693-8f63-4766-85f1-250a427f1db5",
+		"ffffffff-ffff-4fff-bfff-ffffffffffff",
+		"00000000-0000-4000-8000-000000000001",
+		"00000000-0000-4000-8000-000000000000",
+	} {
+		next, err := original.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := next.Prev()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != original {
+			t.Errorf("want: %v, got: %v", original, got)
+		}
+	}
+
+	// wraps around below zero
+	got, err := UUID("2cc5765f-5192-4f18-bf38-278eaaf64718").Prev()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := UUID("ffffffff-ffff-4fff-bfff-ffffffffffff"); got != want {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+
+	if got, err := Nil.Prev(); err != nil || got != Nil {
+		t.Errorf("want Nil for Nil, got: %v, %v", got, err)
+	}
+
+	for _, orig := range []UUID{Max, "asda"} {
+		if _, err := orig.Prev(); err == nil {
+			t.Errorf("expected error, but got nothing for %v", orig)
+		}
+	}
+}
+
+func TestPrevNextRoundTrip(t *testing.T) {
+	for i := 0; i < 10000; i++ {
+		u := NewV4()
+
+		next, err := u.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back, err := next.Prev(); err != nil || back != u {
+			t.Fatalf("%v.Next().Prev() want: %v, got: %v, %v", u, u, back, err)
+		}
+
+		prev, err := u.Prev()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back, err := prev.Next(); err != nil || back != u {
+			t.Fatalf("%v.Prev().Next() want: %v, got: %v, %v", u, u, back, err)
+		}
+	}
+}
+
+func TestAdd(t *testing.T) {
+	for _, data := range []struct {
+		original UUID
+		delta    uint64
+		want     UUID
+	}{
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", delta: 0, want: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", delta: 5, want: "afe40693-8f63-4766-85f1-250a427f1dba"},
+		// carry skips the version and variant bytes
+		{original: "00000000-0000-4000-80ff-ffffffffffff", delta: 1, want: "00000000-0000-4001-8000-000000000000"},
+		{original: "00000000-0000-40ff-80ff-ffffffffffff", delta: 1, want: "00000000-0001-4000-8000-000000000000"},
+		// overflow wraps around
+		{original: "ffffffff-ffff-4fff-bfff-ffffffffffff", delta: 1, want: "00000000-0000-4f00-bf00-000000000000"},
+		{original: "ffffffff-ffff-4fff-bfff-ffffffffffff", delta: 3, want: "00000000-0000-4f00-bf00-000000000002"},
+		{original: Nil, delta: 5, want: Nil},
+	} {
+		got, err := data.original.Add(data.delta)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != data.want {
+			t.Errorf("%v.Add(%v) want: %v, got: %v", data.original, data.delta, data.want, got)
+		}
+	}
+
+	u := UUID("afe40693-8f63-4766-85f1-250a427f1db5")
+	a, err := u.Add(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := u.Add(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, err = b.Add(1); err != nil {
+		t.Fatal(err)
+	}
+	if a != b {
+		t.Errorf("Add(2) is different from Add(1).Add(1): %v != %v", a, b)
+	}
+
+	for _, orig := range []UUID{Max, "asda"} {
+		if _, err := orig.Add(1); err == nil {
+			t.Errorf("expected error, but got nothing for %v", orig)
+		}
+	}
+}
+
 func TestXOR(t *testing.T) {
 	a := UUID("afe40693-8f63-4766-85f1-250a427f1db5")
 	b := UUID("43ae2f25-802d-4aae-be57-b7acefe336ac")
@@ -412,6 +1375,9 @@ func TestXOR(t *testing.T) {
 	if aXb != bXa {
 		t.Errorf("a xor b is different from b xor a, %v != %v", aXb, bXa)
 	}
+	if want := UUID("ec4a29b6-0f4e-4dc8-bba6-92a6ad9c2b19"); aXb != want {
+		t.Errorf("want: %v, got: %v", want, aXb)
+	}
 
 	uid, err := uuid.FromString(aXb.String())
 	if err != nil {
@@ -441,3 +1407,242 @@ func TestXOR(t *testing.T) {
 		t.Errorf("(a xor b) xor b is different from a, %v != %v", aXbXb, a)
 	}
 }
+
+func TestXORStrict(t *testing.T) {
+	a := UUID("afe40693-8f63-4766-85f1-250a427f1db5")
+	b := UUID("43ae2f25-802d-4aae-be57-b7acefe336ac")
+
+	got, err := a.XORStrict(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := UUID("ec4a29b6-0f4e-4dc8-bba6-92a6ad9c2b19"); got != want {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+
+	for _, data := range []struct {
+		name string
+		a, b UUID
+	}{
+		{name: "nil left", a: Nil, b: b},
+		{name: "nil right", a: a, b: Nil},
+		{name: "both nil", a: Nil, b: Nil},
+		{name: "max", a: a, b: Max},
+		{name: "malformed", a: "asda", b: b},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			if _, err := data.a.XORStrict(data.b); err == nil {
+				t.Errorf("expected error, but got nothing for %v, %v", data.a, data.b)
+			}
+		})
+	}
+
+	// the default stays the same
+	if got, err := Nil.XOR(Nil); err != nil || got != Nil {
+		t.Errorf("want Nil for Nil, got: %v, %v", got, err)
+	}
+}
+
+func TestXORRaw(t *testing.T) {
+	for _, data := range []struct {
+		a, b UUID
+	}{
+		{a: "afe40693-8f63-4766-85f1-250a427f1db5", b: "43ae2f25-802d-4aae-be57-b7acefe336ac"},
+		// XOR loses the version and variant bits of these
+		{a: "6ba7b810-9dad-11d1-80b4-00c04fd430c8", b: "43ae2f25-802d-4aae-be57-b7acefe336ac"},
+		{a: "afe40693-8f63-4766-c5f1-250a427f1db5", b: "43ae2f25-802d-4aae-be57-b7acefe336ac"},
+		{a: "afe40693-8f63-4766-85f1-250a427f1db5", b: "6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{a: "afe40693-8f63-4766-85f1-250a427f1db5", b: "afe40693-8f63-4766-85f1-250a427f1db5"},
+		{a: "afe40693-8f63-4766-85f1-250a427f1db5", b: Max},
+		{a: "afe40693-8f63-4766-85f1-250a427f1db5", b: Nil},
+		{a: Nil, b: "43ae2f25-802d-4aae-be57-b7acefe336ac"},
+		{a: Nil, b: Nil},
+	} {
+		x, err := XORRaw(data.a, data.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := XORRaw(x, data.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != data.a {
+			t.Errorf("XORRaw(XORRaw(%v, %v), %v) want: %v, got: %v", data.a, data.b, data.b, data.a, got)
+		}
+
+		if y, err := XORRaw(data.b, data.a); err != nil || y != x {
+			t.Errorf("XORRaw(%v, %v) is different from XORRaw(%v, %v), %v != %v", data.a, data.b, data.b, data.a, x, y)
+		}
+	}
+
+	got, err := XORRaw("6ba7b810-9dad-11d1-80b4-00c04fd430c8", "43ae2f25-802d-4aae-be57-b7acefe336ac")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := UUID("28099735-1d80-5b7f-3ee3-b76ca0370664"); got != want {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+
+	// XOR is not reversible for these
+	x, err := UUID("6ba7b810-9dad-11d1-80b4-00c04fd430c8").XOR("43ae2f25-802d-4aae-be57-b7acefe336ac")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if back, _ := x.XOR("43ae2f25-802d-4aae-be57-b7acefe336ac"); back == "6ba7b810-9dad-11d1-80b4-00c04fd430c8" {
+		t.Errorf("expected XOR to lose the version bits, got: %v", back)
+	}
+
+	for _, data := range []struct {
+		name string
+		a, b UUID
+	}{
+		{name: "malformed left", a: "asda", b: "43ae2f25-802d-4aae-be57-b7acefe336ac"},
+		{name: "malformed right", a: "43ae2f25-802d-4aae-be57-b7acefe336ac", b: "asda"},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			if _, err := XORRaw(data.a, data.b); err == nil {
+				t.Errorf("expected error, but got nothing for %v, %v", data.a, data.b)
+			}
+		})
+	}
+}
+
+func TestXORAll(t *testing.T) {
+	a := UUID("afe40693-8f63-4766-85f1-250a427f1db5")
+	b := UUID("43ae2f25-802d-4aae-be57-b7acefe336ac")
+	c := NewV4()
+
+	aXb, err := a.XOR(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aXbXc, err := aXb.XOR(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, data := range []struct {
+		uuids []UUID
+		want  UUID
+	}{
+		{uuids: []UUID{a, b}, want: aXb},
+		{uuids: []UUID{b, a}, want: aXb},
+		{uuids: []UUID{a, b, c}, want: aXbXc},
+		{uuids: []UUID{c, a, b}, want: aXbXc},
+		{uuids: []UUID{b, c, a}, want: aXbXc},
+		{uuids: []UUID{Nil, a, Nil, b}, want: aXb},
+		{uuids: []UUID{a}, want: a},
+		{uuids: []UUID{Nil, Nil}, want: Nil},
+		{uuids: nil, want: Nil},
+	} {
+		got, err := XORAll(data.uuids...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != data.want {
+			t.Errorf("XORAll(%v) want: %v, got: %v", data.uuids, data.want, got)
+		}
+	}
+
+	for _, data := range []struct {
+		name  string
+		uuids []UUID
+	}{
+		{name: "max", uuids: []UUID{a, Max}},
+		{name: "malformed", uuids: []UUID{a, b, "asda"}},
+	} {
+		t.Run(data.name, func(t *testing.T) {
+			if _, err := XORAll(data.uuids...); err == nil {
+				t.Errorf("expected error, but got nothing for %v", data.uuids)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for orig := range tests {
+		if err := UUID(orig).Validate(); err != nil {
+			t.Errorf("unexpected error for %q: %v", orig, err)
+		}
+	}
+
+	for _, orig := range testErrors {
+		t.Run(orig, func(t *testing.T) {
+			err := UUID(orig).Validate()
+			if err == nil {
+				t.Fatalf("expected error, but got nothing for %v", orig)
+			}
+
+			_, want := FromString(orig)
+			if err.Error() != want.Error() {
+				t.Errorf("want: %v, got: %v", want, err)
+			}
+		})
+	}
+}
+
+func TestIsNil(t *testing.T) {
+	for _, data := range []struct {
+		original UUID
+		want     bool
+	}{
+		{original: Nil, want: true},
+		{original: UUID(""), want: true},
+		{original: "00000000-0000-0000-0000-000000000000", want: true},
+		{original: "00000000-0000-4000-8000-000000000000", want: false},
+		{original: "00000000000000000000000000000000", want: false},
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", want: false},
+		{original: Max, want: false},
+	} {
+		if got := data.original.IsNil(); got != data.want {
+			t.Errorf("IsNil(%q) want: %v, got: %v", data.original, data.want, got)
+		}
+	}
+
+	parsed, err := FromString("00000000-0000-0000-0000-000000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !parsed.IsNil() {
+		t.Errorf("expected the parsed zero uuid to be nil, got: %q", parsed)
+	}
+}
+
+func TestMax(t *testing.T) {
+	if !Max.IsMax() {
+		t.Error("Max.IsMax() is false")
+	}
+
+	for _, u := range []UUID{Nil, "afe40693-8f63-4766-85f1-250a427f1db5", "FFFFFFFF-FFFF-FFFF-FFFF-FFFFFFFFFFFF"} {
+		if u.IsMax() {
+			t.Errorf("%v.IsMax() is true", u)
+		}
+	}
+
+	if got := Max.HashLike(); got != "ffffffffffffffffffffffffffffffff" {
+		t.Errorf("want: %v, got: %v", "ffffffffffffffffffffffffffffffff", got)
+	}
+
+	var scanValue UUID
+	if err := scanValue.Scan([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}); err != nil {
+		t.Fatal(err)
+	}
+	if scanValue != Max {
+		t.Errorf("want: %v, got: %v", Max, scanValue)
+	}
+}
+
+func TestMaxNextXOR(t *testing.T) {
+	if _, err := Max.Next(); err == nil {
+		t.Error("expected error, but got nothing for Max.Next()")
+	}
+
+	a := UUID("afe40693-8f63-4766-85f1-250a427f1db5")
+	if _, err := a.XOR(Max); err == nil {
+		t.Error("expected error, but got nothing for a.XOR(Max)")
+	}
+	if _, err := Max.XOR(a); err == nil {
+		t.Error("expected error, but got nothing for Max.XOR(a)")
+	}
+}
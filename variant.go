@@ -0,0 +1,52 @@
+package uuid
+
+// Variant is the layout of the uuid as defined by the variant bits in byte 8, see RFC 4122 section 4.1.1.
+type Variant byte
+
+const (
+	// VariantInvalid is returned for Nil and malformed uuids.
+	VariantInvalid Variant = iota
+	// VariantNCS is reserved for NCS backward compatibility (0xxx).
+	VariantNCS
+	// VariantRFC4122 is the variant of RFC 4122 uuids (10xx), eg: NewV4 and NewTime.
+	VariantRFC4122
+	// VariantMicrosoft is reserved for Microsoft backward compatibility (110x).
+	VariantMicrosoft
+	// VariantFuture is reserved for future definition (111x).
+	VariantFuture
+)
+
+var variantNames = [...]string{
+	VariantInvalid:   "Invalid",
+	VariantNCS:       "NCS",
+	VariantRFC4122:   "RFC4122",
+	VariantMicrosoft: "Microsoft",
+	VariantFuture:    "Future",
+}
+
+func (v Variant) String() string {
+	if int(v) >= len(variantNames) {
+		return "Invalid"
+	}
+
+	return variantNames[v]
+}
+
+// Variant returns the variant of the uuid, VariantInvalid for Nil and malformed uuids.
+func (u UUID) Variant() Variant {
+	b, err := u.decode()
+	if err != nil {
+		return VariantInvalid
+	}
+
+	switch {
+	case b[8]&0x80 == 0x00:
+		return VariantNCS
+	case b[8]&0xc0 == 0x80:
+		return VariantRFC4122
+	case b[8]&0xe0 == 0xc0:
+		return VariantMicrosoft
+	default:
+		return VariantFuture
+	}
+}
@@ -0,0 +1,66 @@
+package uuid
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+)
+
+func TestVariant(t *testing.T) {
+	for _, data := range []struct {
+		original UUID
+		want     Variant
+	}{
+		{original: "afe40693-8f63-4766-05f1-250a427f1db5", want: VariantNCS},
+		{original: "afe40693-8f63-4766-75f1-250a427f1db5", want: VariantNCS},
+		{original: "afe40693-8f63-4766-85f1-250a427f1db5", want: VariantRFC4122},
+		{original: "afe40693-8f63-4766-b5f1-250a427f1db5", want: VariantRFC4122},
+		{original: "afe40693-8f63-4766-c5f1-250a427f1db5", want: VariantMicrosoft},
+		{original: "afe40693-8f63-4766-d5f1-250a427f1db5", want: VariantMicrosoft},
+		{original: "afe40693-8f63-4766-e5f1-250a427f1db5", want: VariantFuture},
+		{original: "afe40693-8f63-4766-f5f1-250a427f1db5", want: VariantFuture},
+		{original: Max, want: VariantFuture},
+	} {
+		got := data.original.Variant()
+		if got != data.want {
+			t.Errorf("invalid variant of %v, want: %v, got: %v", data.original, data.want, got)
+		}
+
+		uid, err := uuid.FromString(data.original.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if byte(got)-1 != uid.Variant() {
+			t.Errorf("variant of %v is different from gofrs, want: %v, got: %v", data.original, uid.Variant(), got)
+		}
+	}
+
+	for _, u := range []UUID{NewV4(), NewTime(Time(0))} {
+		if got := u.Variant(); got != VariantRFC4122 {
+			t.Errorf("invalid variant of %v, want: %v, got: %v", u, VariantRFC4122, got)
+		}
+	}
+	for _, u := range []UUID{Nil, "asda"} {
+		if got := u.Variant(); got != VariantInvalid {
+			t.Errorf("invalid variant of %q, want: %v, got: %v", u, VariantInvalid, got)
+		}
+	}
+}
+
+func TestVariantString(t *testing.T) {
+	for _, data := range []struct {
+		variant Variant
+		want    string
+	}{
+		{variant: VariantInvalid, want: "Invalid"},
+		{variant: VariantNCS, want: "NCS"},
+		{variant: VariantRFC4122, want: "RFC4122"},
+		{variant: VariantMicrosoft, want: "Microsoft"},
+		{variant: VariantFuture, want: "Future"},
+		{variant: Variant(42), want: "Invalid"},
+	} {
+		if got := data.variant.String(); got != data.want {
+			t.Errorf("want: %v, got: %v", data.want, got)
+		}
+	}
+}